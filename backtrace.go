@@ -0,0 +1,102 @@
+package logger
+
+import "io"
+
+// NewBacktrace creates a new backtrace.
+//
+// Set it to the logger with SetBacktrace, or to a logger copy with WithBacktrace,
+// like the logger of a request, to scope the recorded entries.
+func NewBacktrace(cfg BacktraceConfig) *Backtrace {
+	if cfg.Level == nil {
+		cfg.Level = defaultBacktraceLevel
+	}
+
+	if cfg.FlushLevel == nil {
+		cfg.FlushLevel = defaultBacktraceFlushLevel
+	}
+
+	if cfg.Size <= 0 {
+		cfg.Size = defaultBacktraceSize
+	}
+
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultBacktraceMaxBytes
+	}
+
+	return &Backtrace{
+		cfg:     cfg,
+		entries: make([]backtraceEntry, cfg.Size),
+	}
+}
+
+// Len returns the number of recorded entries.
+func (b *Backtrace) Len() int {
+	b.mu.Lock()
+	n := b.count
+	b.mu.Unlock()
+
+	return n
+}
+
+// Reset discards the recorded entries.
+func (b *Backtrace) Reset() {
+	b.mu.Lock()
+	b.reset()
+	b.mu.Unlock()
+}
+
+func (b *Backtrace) reset() {
+	b.head = 0
+	b.count = 0
+	b.bytes = 0
+}
+
+// records returns whether the given level is recorded, when disabled by the logger level.
+func (b *Backtrace) records(level Level) bool {
+	return b.cfg.Level.Enabled(level)
+}
+
+// flushes returns whether the given level writes the recorded entries.
+func (b *Backtrace) flushes(level Level) bool {
+	return b.cfg.FlushLevel.Enabled(level)
+}
+
+// add records a copy of the encoded entry, discarding the oldest ones if full.
+//
+// The entries bigger than the maximum size are not recorded.
+func (b *Backtrace) add(level Level, p []byte) {
+	if len(p) > b.cfg.MaxBytes {
+		return
+	}
+
+	b.mu.Lock()
+
+	for b.count > 0 && (b.count == len(b.entries) || b.bytes+len(p) > b.cfg.MaxBytes) {
+		b.bytes -= len(b.entries[b.head].data)
+		b.head = (b.head + 1) % len(b.entries)
+		b.count--
+	}
+
+	entry := &b.entries[(b.head+b.count)%len(b.entries)]
+	entry.level = level
+	entry.data = append(entry.data[:0], p...)
+
+	b.bytes += len(p)
+	b.count++
+
+	b.mu.Unlock()
+}
+
+// flush writes the recorded entries to w, from the oldest, and discards them.
+func (b *Backtrace) flush(w io.Writer) {
+	b.mu.Lock()
+
+	for i := 0; i < b.count; i++ {
+		entry := b.entries[(b.head+i)%len(b.entries)]
+		writeLevel(w, entry.level, entry.data) // nolint:errcheck
+	}
+
+	b.reset()
+
+	b.mu.Unlock()
+}
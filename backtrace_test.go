@@ -0,0 +1,269 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func backtraceEntries(b *Backtrace) []string {
+	output := new(bytes.Buffer)
+	b.flush(output)
+
+	return strings.SplitAfter(output.String(), "\n")[:strings.Count(output.String(), "\n")]
+}
+
+func Test_NewBacktrace(t *testing.T) {
+	b := NewBacktrace(BacktraceConfig{})
+
+	if b.cfg.Level != defaultBacktraceLevel {
+		t.Errorf("Level == %v, want %v", b.cfg.Level, defaultBacktraceLevel)
+	}
+
+	if b.cfg.FlushLevel != defaultBacktraceFlushLevel {
+		t.Errorf("FlushLevel == %v, want %v", b.cfg.FlushLevel, defaultBacktraceFlushLevel)
+	}
+
+	if b.cfg.Size != defaultBacktraceSize {
+		t.Errorf("Size == %d, want %d", b.cfg.Size, defaultBacktraceSize)
+	}
+
+	if b.cfg.MaxBytes != defaultBacktraceMaxBytes {
+		t.Errorf("MaxBytes == %d, want %d", b.cfg.MaxBytes, defaultBacktraceMaxBytes)
+	}
+
+	if len(b.entries) != defaultBacktraceSize {
+		t.Errorf("entries == %d, want %d", len(b.entries), defaultBacktraceSize)
+	}
+}
+
+func TestBacktrace_add(t *testing.T) { // nolint:funlen
+	type args struct {
+		cfg     BacktraceConfig
+		entries []string
+	}
+
+	type want struct {
+		entries []string
+		bytes   int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Empty",
+			args: args{},
+			want: want{entries: []string{}},
+		},
+		{
+			name: "NotFull",
+			args: args{
+				entries: []string{"a\n", "b\n"},
+			},
+			want: want{entries: []string{"a\n", "b\n"}, bytes: 4},
+		},
+		{
+			name: "Size",
+			args: args{
+				cfg:     BacktraceConfig{Size: 2},
+				entries: []string{"a\n", "b\n", "c\n", "d\n", "e\n"},
+			},
+			want: want{entries: []string{"d\n", "e\n"}, bytes: 4},
+		},
+		{
+			name: "MaxBytes",
+			args: args{
+				cfg:     BacktraceConfig{MaxBytes: 6},
+				entries: []string{"aa\n", "b\n", "cc\n"},
+			},
+			want: want{entries: []string{"b\n", "cc\n"}, bytes: 5},
+		},
+		{
+			name: "TooBig",
+			args: args{
+				cfg:     BacktraceConfig{MaxBytes: 3},
+				entries: []string{"a\n", "bbbb\n"},
+			},
+			want: want{entries: []string{"a\n"}, bytes: 2},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			b := NewBacktrace(test.args.cfg)
+
+			for _, entry := range test.args.entries {
+				b.add(DEBUG, []byte(entry))
+			}
+
+			if b.Len() != len(test.want.entries) {
+				t.Errorf("len == %d, want %d", b.Len(), len(test.want.entries))
+			}
+
+			if b.bytes != test.want.bytes {
+				t.Errorf("bytes == %d, want %d", b.bytes, test.want.bytes)
+			}
+
+			if entries := backtraceEntries(b); strings.Join(entries, "") != strings.Join(test.want.entries, "") {
+				t.Errorf("entries == %q, want %q", entries, test.want.entries)
+			}
+
+			if b.Len() != 0 || b.bytes != 0 {
+				t.Errorf("len/bytes == %d/%d, want 0/0", b.Len(), b.bytes)
+			}
+		})
+	}
+}
+
+func TestBacktrace_add_copy(t *testing.T) {
+	b := NewBacktrace(BacktraceConfig{})
+
+	p := []byte("foo\n")
+	b.add(DEBUG, p)
+	copy(p, "bar\n")
+
+	if entries := backtraceEntries(b); entries[0] != "foo\n" {
+		t.Errorf("entry == %q, want %q", entries[0], "foo\n")
+	}
+}
+
+func TestBacktrace_flush_level(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	b := NewBacktrace(BacktraceConfig{})
+	b.add(DEBUG, []byte("debug\n"))
+	b.add(TRACE, []byte("trace\n"))
+	b.flush(NewLevelFilterWriter(LevelExact(DEBUG), output))
+
+	if want := "debug\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestBacktrace_Reset(t *testing.T) {
+	b := NewBacktrace(BacktraceConfig{})
+	b.add(DEBUG, []byte("debug\n"))
+	b.Reset()
+
+	if b.Len() != 0 {
+		t.Errorf("len == %d, want %d", b.Len(), 0)
+	}
+}
+
+func TestLogger_backtrace(t *testing.T) { // nolint:funlen
+	type args struct {
+		cfg BacktraceConfig
+		log func(l *Logger)
+	}
+
+	type want struct {
+		output string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "NoError",
+			args: args{
+				log: func(l *Logger) {
+					l.Debug("debug")
+					l.Trace("trace")
+					l.Info("info")
+				},
+			},
+			want: want{output: "INFO - info\n"},
+		},
+		{
+			name: "Error",
+			args: args{
+				log: func(l *Logger) {
+					l.Debug("debug")
+					l.Info("info")
+					l.Trace("trace")
+					l.Error("error 1")
+					l.Error("error 2")
+					l.Debug("debug 2")
+					l.Warning("warning")
+				},
+			},
+			want: want{
+				output: "INFO - info\nDEBUG - debug\nTRACE - trace\nERROR - error 1\nERROR - error 2\nWARNING - warning\n",
+			},
+		},
+		{
+			name: "Print",
+			args: args{
+				log: func(l *Logger) {
+					l.Debug("debug")
+					l.Print("print")
+					l.Error("error")
+				},
+			},
+			want: want{output: "print\nDEBUG - debug\nERROR - error\n"},
+		},
+		{
+			name: "Level",
+			args: args{
+				cfg: BacktraceConfig{Level: DEBUG},
+				log: func(l *Logger) {
+					l.Debug("debug")
+					l.Trace("trace")
+					l.Error("error")
+				},
+			},
+			want: want{output: "DEBUG - debug\nERROR - error\n"},
+		},
+		{
+			name: "FlushLevel",
+			args: args{
+				cfg: BacktraceConfig{FlushLevel: WARNING},
+				log: func(l *Logger) {
+					l.Debug("debug")
+					l.Warning("warning")
+				},
+			},
+			want: want{output: "DEBUG - debug\nWARNING - warning\n"},
+		},
+		{
+			name: "Scoped",
+			args: args{
+				log: func(l *Logger) {
+					req1 := l.WithFields(String("req", "1")).WithBacktrace(NewBacktrace(BacktraceConfig{}))
+					req2 := l.WithFields(String("req", "2")).WithBacktrace(NewBacktrace(BacktraceConfig{}))
+
+					req1.Debug("debug")
+					req2.Debug("debug")
+					req2.WithFields(Int("n", 1)).Debug("debug")
+					req2.Error("error")
+				},
+			},
+			want: want{output: "DEBUG - req=2 - debug\nDEBUG - req=2 - n=1 - debug\nERROR - req=2 - error\n"},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			output := new(bytes.Buffer)
+
+			l := New(INFO, output)
+			l.SetFlags(0)
+			l.SetBacktrace(NewBacktrace(test.args.cfg))
+
+			test.args.log(l)
+
+			if output.String() != test.want.output {
+				t.Errorf("output == %q, want %q", output.String(), test.want.output)
+			}
+		})
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	gstrconv "github.com/savsgio/gotils/strconv"
 	"github.com/valyala/bytebufferpool"
@@ -35,6 +38,15 @@ func ReleaseBuffer(b *Buffer) {
 	bufferPool.Put(b)
 }
 
+// callerPCs returns the slice of program counters reused to get the caller.
+func (b *Buffer) callerPCs() []uintptr {
+	if b.pcs == nil {
+		b.pcs = make([]uintptr, maxCallerDepth)
+	}
+
+	return b.pcs
+}
+
 func (b *Buffer) hasBytesSpecialChars(value []byte) bool {
 	if bytes.IndexByte(value, '"') >= 0 || bytes.IndexByte(value, '\\') >= 0 {
 		return true
@@ -59,6 +71,86 @@ func (b *Buffer) writeEscapedBytes(value []byte) {
 	bytebufferpool.Put(str)
 }
 
+func (b *Buffer) isControlRune(r rune, multiline bool) bool {
+	switch {
+	case r == '\n' || r == '\t':
+		return !multiline
+	case r < 0x20 || r == 0x7f:
+		return true
+	case r >= 0x80 && r <= 0x9f, r == '\u2028', r == '\u2029':
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *Buffer) hasBytesControlChars(value []byte, multiline bool) bool {
+	for i := 0; i < len(value); {
+		if value[i] < utf8.RuneSelf {
+			if b.isControlRune(rune(value[i]), multiline) {
+				return true
+			}
+
+			i++
+
+			continue
+		}
+
+		r, size := utf8.DecodeRune(value[i:])
+		if (r == utf8.RuneError && size == 1) || b.isControlRune(r, multiline) {
+			return true
+		}
+
+		i += size
+	}
+
+	return false
+}
+
+func (b *Buffer) writeEscapedControlBytes(value []byte, multiline bool) {
+	str := bytebufferpool.Get()
+	str.Set(value) // NOTE: Use as a copy of b.
+
+	for i := 0; i < str.Len(); {
+		r, size := rune(str.B[i]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRune(str.B[i:])
+		}
+
+		switch {
+		case r == utf8.RuneError && size == 1:
+			// NOTE: The invalid bytes are escaped too, like the 8-bit CSI (0x9b),
+			// since the terminals could interpret them.
+			b.WriteString(`\x`) // nolint:errcheck
+			b.writeHex(uint64(str.B[i]), 2)
+		case !b.isControlRune(r, multiline):
+			b.Write(str.B[i : i+size]) // nolint:errcheck
+		case r == '\n':
+			b.WriteString(`\n`) // nolint:errcheck
+		case r == '\r':
+			b.WriteString(`\r`) // nolint:errcheck
+		case r == '\t':
+			b.WriteString(`\t`) // nolint:errcheck
+		case r < utf8.RuneSelf:
+			b.WriteString(`\x`) // nolint:errcheck
+			b.writeHex(uint64(r), 2)
+		default:
+			b.WriteString(`\u`) // nolint:errcheck
+			b.writeHex(uint64(r), 4)
+		}
+
+		i += size
+	}
+
+	bytebufferpool.Put(str)
+}
+
+func (b *Buffer) writeHex(value uint64, width int) {
+	for i := width - 1; i >= 0; i-- {
+		b.WriteByte(hexDigits[(value>>(uint(i)*4))&0xf]) // nolint:errcheck
+	}
+}
+
 func (b *Buffer) formatMessage(msg string, args []interface{}) string {
 	b.b2.Reset()
 
@@ -84,6 +176,19 @@ func (b *Buffer) formatMessage(msg string, args []interface{}) string {
 	return b.b2.String()
 }
 
+func (b *Buffer) truncate(n int) {
+	b.b1.B = b.b1.B[:n]
+}
+
+// closeJSON writes the given closing char, replacing the trailing comma if any.
+func (b *Buffer) closeJSON(c byte) {
+	if length := b.Len(); length > 0 && b.b1.B[length-1] == ',' {
+		b.b1.B[length-1] = c
+	} else {
+		b.WriteByte(c) // nolint:errcheck
+	}
+}
+
 // Reset clears the buffer.
 func (b *Buffer) Reset() {
 	b.b1.Reset()
@@ -113,6 +218,17 @@ func (b *Buffer) Escape(startAt int) {
 	}
 }
 
+// EscapeControl escapes the control characters, like new lines or ANSI escape sequences,
+// of accumulated bytes since the given index.
+//
+// If multiline is true, the new lines and tabs are kept.
+func (b *Buffer) EscapeControl(startAt int, multiline bool) {
+	if value := b.b1.B[startAt:]; b.hasBytesControlChars(value, multiline) {
+		b.b1.Set(b.b1.B[:startAt])
+		b.writeEscapedControlBytes(value, multiline)
+	}
+}
+
 // Write writes the given bytes slice to the buffer.
 func (b *Buffer) Write(s []byte) (int, error) {
 	return b.b1.Write(s) // nolint:wrapcheck
@@ -140,12 +256,39 @@ func (b *Buffer) WriteDatetime(now time.Time, layout string) {
 
 // WriteTimestamp writes the timestamp to the buffer from the given time.
 func (b *Buffer) WriteTimestamp(now time.Time, format TimestampFormat) {
-	fn := now.Unix
-	if format == TimestampFormatNanoseconds {
-		fn = now.UnixNano
+	switch format {
+	case TimestampFormatNanoseconds:
+		b.b1.B = strconv.AppendInt(b.b1.B, now.UnixNano(), 10)
+	case TimestampFormatMilliseconds:
+		b.b1.B = strconv.AppendInt(b.b1.B, now.Unix()*1e3+int64(now.Nanosecond())/1e6, 10)
+	case TimestampFormatMicroseconds:
+		b.b1.B = strconv.AppendInt(b.b1.B, now.Unix()*1e6+int64(now.Nanosecond())/1e3, 10)
+	case TimestampFormatFloatSeconds:
+		b.writeFloatSeconds(now)
+	default:
+		b.b1.B = strconv.AppendInt(b.b1.B, now.Unix(), 10)
+	}
+}
+
+// writeFloatSeconds writes the seconds with the fraction of nanoseconds, without rounding errors.
+func (b *Buffer) writeFloatSeconds(now time.Time) {
+	sec, nsec := now.Unix(), int64(now.Nanosecond())
+
+	if sec < 0 && nsec > 0 {
+		sec++
+		nsec = 1e9 - nsec
+
+		if sec == 0 {
+			b.b1.B = append(b.b1.B, '-')
+		}
 	}
 
-	b.b1.B = strconv.AppendInt(b.b1.B, fn(), 10)
+	b.b1.B = strconv.AppendInt(b.b1.B, sec, 10)
+	b.b1.B = append(b.b1.B, '.')
+
+	for div := int64(1e8); div > 0; div /= 10 {
+		b.b1.B = append(b.b1.B, byte('0'+nsec/div%10))
+	}
 }
 
 // WriteFileCaller writes the file caller to the buffer.
@@ -153,18 +296,28 @@ func (b *Buffer) WriteFileCaller(f runtime.Frame, short bool) {
 	file := f.File
 
 	if short {
-		for i := len(file) - 1; i > 0; i-- {
-			if file[i] == '/' {
-				file = file[i+1:]
+		file = shortFile(file)
+	}
 
-				break
-			}
-		}
+	b.writeFileLine(file, f.Line)
+}
+
+// writeEntryFileCaller writes the file caller of the entry,
+// using the cached short file name of the caller if it's still the same.
+func (b *Buffer) writeEntryFileCaller(e *Entry) {
+	if !e.Config.Shortfile || e.callerShortFile == "" || !strings.HasSuffix(e.Caller.File, e.callerShortFile) {
+		b.WriteFileCaller(e.Caller, e.Config.Shortfile)
+
+		return
 	}
 
+	b.writeFileLine(e.callerShortFile, e.Caller.Line)
+}
+
+func (b *Buffer) writeFileLine(file string, line int) {
 	b.WriteString(file) // nolint:errcheck
 	b.WriteByte(':')    // nolint:errcheck
-	b.b1.B = strconv.AppendInt(b.b1.B, int64(f.Line), 10)
+	b.b1.B = strconv.AppendInt(b.b1.B, int64(line), 10)
 }
 
 // WriteInterface writes an interface value to the buffer.
@@ -176,6 +329,35 @@ func (b *Buffer) WriteInterface(value interface{}) {
 	}
 }
 
+// WriteDuration writes the given duration to the buffer, formatted like time.Duration.String.
+func (b *Buffer) WriteDuration(d time.Duration) {
+	b.b1.B = appendDuration(b.b1.B, d)
+}
+
+// WriteFieldValue writes the field value to the buffer,
+// formatting the time values with the given layout.
+func (b *Buffer) WriteFieldValue(f Field, datetimeLayout string) {
+	switch f.typ {
+	case fieldTypeString, fieldTypeBytes:
+		b.WriteString(f.str) // nolint:errcheck
+	case fieldTypeInt64:
+		b.b1.B = strconv.AppendInt(b.b1.B, f.integer, 10)
+	case fieldTypeFloat64:
+		b.b1.B = strconv.AppendFloat(b.b1.B, math.Float64frombits(uint64(f.integer)), 'g', -1, 64)
+	case fieldTypeBool:
+		b.b1.B = strconv.AppendBool(b.b1.B, f.integer == 1)
+	case fieldTypeDuration:
+		b.WriteDuration(time.Duration(f.integer))
+	case fieldTypeTime:
+		b.WriteDatetime(f.time(), datetimeLayout)
+	case fieldTypeNamespace:
+	case fieldTypeAny, fieldTypeStringer, fieldTypeObject, fieldTypeArray:
+		fallthrough
+	default:
+		b.WriteInterface(f.Value)
+	}
+}
+
 // WriteNewLine writes a new line to the buffer if it's needed.
 func (b *Buffer) WriteNewLine() {
 	if length := b.Len(); length > 0 && b.b1.B[length-1] != '\n' {
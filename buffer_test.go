@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
-	"strconv"
 	"testing"
 	"time"
 )
@@ -130,6 +129,157 @@ func TestBuffer_writeEscapedBytes(t *testing.T) { // nolint:funlen
 	}
 }
 
+func TestBuffer_hasBytesControlChars(t *testing.T) { // nolint:funlen
+	type args struct {
+		value     []byte
+		multiline bool
+	}
+
+	type want struct {
+		result bool
+	}
+
+	tests := []struct {
+		args args
+		want want
+	}{
+		{
+			args: args{
+				value: []byte(`some "string" with ñ and 😀`),
+			},
+			want: want{
+				result: false,
+			},
+		},
+		{
+			args: args{
+				value: []byte("some\nstring"),
+			},
+			want: want{
+				result: true,
+			},
+		},
+		{
+			args: args{
+				value:     []byte("some\n\tstring"),
+				multiline: true,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		{
+			args: args{
+				value:     []byte("some \x1b[31mstring"),
+				multiline: true,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		{
+			args: args{
+				value: []byte("some \u2028string"),
+			},
+			want: want{
+				result: true,
+			},
+		},
+		{
+			args: args{
+				value: []byte("some \u009bstring"),
+			},
+			want: want{
+				result: true,
+			},
+		},
+		{
+			args: args{
+				value: []byte("some \x9b31mstring"),
+			},
+			want: want{
+				result: true,
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run("", func(t *testing.T) {
+			buf := NewBuffer()
+
+			if result := buf.hasBytesControlChars(test.args.value, test.args.multiline); result != test.want.result {
+				t.Errorf("value == %q, result = %t, want %t", test.args.value, result, test.want.result)
+			}
+		})
+	}
+}
+
+func TestBuffer_writeEscapedControlBytes(t *testing.T) { // nolint:funlen
+	type args struct {
+		value     []byte
+		multiline bool
+	}
+
+	type want struct {
+		result string
+	}
+
+	tests := []struct {
+		args args
+		want want
+	}{
+		{
+			args: args{
+				value: []byte(`some "string" with ñ`),
+			},
+			want: want{
+				result: `some "string" with ñ`,
+			},
+		},
+		{
+			args: args{
+				value: []byte("line1\r\nline2\t\x1b[0m\x00\x7f\u2028\xff"),
+			},
+			want: want{
+				result: `line1\r\nline2\t\x1b[0m\x00\x7f\u2028\xff`,
+			},
+		},
+		{
+			args: args{
+				value:     []byte("line1\r\nline2\t\x1b[0m"),
+				multiline: true,
+			},
+			want: want{
+				result: "line1\\r\nline2\t\\x1b[0m",
+			},
+		},
+		{
+			args: args{
+				value:     []byte("red \x9b31mtext ñ"),
+				multiline: true,
+			},
+			want: want{
+				result: `red \x9b31mtext ñ`,
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run("", func(t *testing.T) {
+			buf := NewBuffer()
+			buf.writeEscapedControlBytes(test.args.value, test.args.multiline)
+
+			if result := buf.String(); result != test.want.result {
+				t.Errorf("value == %q, result = %q, want %q", test.args.value, result, test.want.result)
+			}
+		})
+	}
+}
+
 func TestBuffer_formatMessage(t *testing.T) { // nolint:funlen
 	type args struct {
 		msg  string
@@ -316,6 +466,31 @@ func TestBuffer_Escape(t *testing.T) { // nolint:funlen
 	}
 }
 
+func TestBuffer_EscapeControl(t *testing.T) {
+	line := "test line\n"
+	value := "some\nstring"
+
+	buf := NewBuffer()
+	buf.WriteString(line)  // nolint:errcheck
+	buf.WriteString(value) // nolint:errcheck
+
+	buf.EscapeControl(len(line), false)
+
+	if result, want := buf.String(), line+`some\nstring`; result != want {
+		t.Errorf("result == %q, want %q", result, want)
+	}
+
+	buf.Reset()
+	buf.WriteString(line)  // nolint:errcheck
+	buf.WriteString(value) // nolint:errcheck
+
+	buf.EscapeControl(len(line), true)
+
+	if result, want := buf.String(), line+value; result != want {
+		t.Errorf("result == %q, want %q", result, want)
+	}
+}
+
 func TestBuffer_Write(t *testing.T) {
 	buf := NewBuffer()
 	wantValue := []byte("hello world")
@@ -377,28 +552,101 @@ func TestBuffer_WriteDatetime(t *testing.T) {
 	}
 }
 
-func TestBuffer_WriteTimestamp(t *testing.T) {
+func TestBuffer_WriteTimestamp(t *testing.T) { // nolint:funlen
 	type args struct {
+		now    time.Time
 		format TimestampFormat
-		tsFunc func(t time.Time) int64
 	}
 
+	type want struct {
+		result string
+	}
+
+	now := time.Date(2006, 1, 2, 15, 4, 5, 123456789, time.UTC)
+
 	tests := []struct {
 		name string
 		args args
+		want want
 	}{
 		{
 			name: "seconds",
 			args: args{
+				now:    now,
 				format: TimestampFormatSeconds,
-				tsFunc: func(t time.Time) int64 { return t.Unix() },
+			},
+			want: want{
+				result: "1136214245",
 			},
 		},
 		{
 			name: "nanoseconds",
 			args: args{
+				now:    now,
 				format: TimestampFormatNanoseconds,
-				tsFunc: func(t time.Time) int64 { return t.UnixNano() },
+			},
+			want: want{
+				result: "1136214245123456789",
+			},
+		},
+		{
+			name: "milliseconds",
+			args: args{
+				now:    now,
+				format: TimestampFormatMilliseconds,
+			},
+			want: want{
+				result: "1136214245123",
+			},
+		},
+		{
+			name: "microseconds",
+			args: args{
+				now:    now,
+				format: TimestampFormatMicroseconds,
+			},
+			want: want{
+				result: "1136214245123456",
+			},
+		},
+		{
+			name: "float seconds",
+			args: args{
+				now:    now,
+				format: TimestampFormatFloatSeconds,
+			},
+			want: want{
+				result: "1136214245.123456789",
+			},
+		},
+		{
+			name: "float seconds without fraction",
+			args: args{
+				now:    time.Unix(1136214245, 0),
+				format: TimestampFormatFloatSeconds,
+			},
+			want: want{
+				result: "1136214245.000000000",
+			},
+		},
+		{
+			name: "float seconds before epoch",
+			args: args{
+				now:    time.Unix(-2, 500000000),
+				format: TimestampFormatFloatSeconds,
+			},
+			want: want{
+				result: "-1.500000000",
+			},
+		},
+		{
+			name: "float seconds before epoch less than one second",
+			args: args{
+				now:    time.Unix(-1, 750000000),
+				format: TimestampFormatFloatSeconds,
+			},
+			want: want{
+				result: "-0.250000000",
 			},
 		},
 	}
@@ -408,21 +656,18 @@ func TestBuffer_WriteTimestamp(t *testing.T) {
 
 		t.Run(test.name, func(t *testing.T) {
 			buf := NewBuffer()
-			now := time.Now()
-
-			buf.WriteTimestamp(now, test.args.format)
 
-			wantTs := strconv.FormatInt(test.args.tsFunc(now), 10) // nolint:stylecheck
+			buf.WriteTimestamp(test.args.now, test.args.format)
 
-			if ts := buf.String(); ts != wantTs {
-				t.Errorf("timestamp == %s, want %s", ts, wantTs)
+			if result := buf.String(); result != test.want.result {
+				t.Errorf("timestamp == %s, want %s", result, test.want.result)
 			}
 		})
 	}
 }
 
 func TestBuffer_WriteFileCaller(t *testing.T) {
-	caller := getFileCaller(2)
+	caller := getFileCaller(nil, 2).frame
 
 	// Short
 	t.Run("Short", func(t *testing.T) {
@@ -463,6 +708,53 @@ func TestBuffer_WriteInterface(t *testing.T) {
 	}
 }
 
+func TestBuffer_WriteDuration(t *testing.T) {
+	durations := []time.Duration{
+		0, 1, 999, time.Microsecond, 1500 * time.Nanosecond, time.Millisecond, 1234567 * time.Nanosecond,
+		time.Second, -time.Second, 90 * time.Second, time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond,
+		1<<63 - 1, -1 << 63,
+	}
+
+	for _, d := range durations {
+		buf := NewBuffer()
+		buf.WriteDuration(d)
+
+		if result := buf.String(); result != d.String() {
+			t.Errorf("result == %s, want %s", result, d.String())
+		}
+	}
+}
+
+func TestBuffer_WriteFieldValue(t *testing.T) { // nolint:funlen
+	now := time.Date(2024, 7, 10, 12, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		field Field
+		want  string
+	}{
+		{field: String("key", "value"), want: "value"},
+		{field: Int64("key", -123), want: "-123"},
+		{field: Float64("key", 1.25), want: "1.25"},
+		{field: Bool("key", true), want: "true"},
+		{field: Duration("key", 1500*time.Millisecond), want: "1.5s"},
+		{field: Time("key", now), want: "2024-07-10T12:30:45Z"},
+		{field: Bytes("key", []byte("value")), want: "value"},
+		{field: Stringer("key", INFO), want: "INFO"},
+		{field: Any("key", []int{1, 2, 3}), want: "[1 2 3]"},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		buf := NewBuffer()
+		buf.WriteFieldValue(test.field, time.RFC3339)
+
+		if result := buf.String(); result != test.want {
+			t.Errorf("result == %s, want %s", result, test.want)
+		}
+	}
+}
+
 func TestBuffer_WriteNewLine(t *testing.T) {
 	buf := NewBuffer()
 
@@ -484,3 +776,18 @@ func TestBuffer_WriteNewLine(t *testing.T) {
 		t.Errorf("line == %s, want %s", bufStr, wantStr)
 	}
 }
+
+func TestBuffer_callerPCs(t *testing.T) {
+	buf := NewBuffer()
+
+	pcs := buf.callerPCs()
+	if len(pcs) != maxCallerDepth {
+		t.Errorf("len == %d, want %d", len(pcs), maxCallerDepth)
+	}
+
+	buf.Reset()
+
+	if again := buf.callerPCs(); &again[0] != &pcs[0] {
+		t.Error("the program counters slice is not reused")
+	}
+}
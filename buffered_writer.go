@@ -0,0 +1,198 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// NewBufferedWriter creates a new buffered writer, which writes to w.
+//
+// NOTE: Call Close to stop the periodic flush and write the remaining data.
+func NewBufferedWriter(w io.Writer, cfg BufferedWriterConfig) *BufferedWriter {
+	if cfg.Size <= 0 {
+		cfg.Size = defaultBufferedWriterSize
+	}
+
+	if cfg.FlushInterval == 0 {
+		cfg.FlushInterval = defaultBufferedWriterFlushInterval
+	}
+
+	if cfg.FlushLevel == nil {
+		cfg.FlushLevel = defaultBufferedWriterFlushLevel
+	}
+
+	bw := &BufferedWriter{
+		cfg:       cfg,
+		w:         w,
+		buf:       make([]byte, 0, cfg.Size),
+		errOutput: os.Stderr,
+		done:      make(chan struct{}),
+	}
+
+	bw.lw, _ = w.(LevelWriter)
+
+	if cfg.FlushInterval > 0 {
+		bw.ticker = time.NewTicker(cfg.FlushInterval)
+
+		go bw.run()
+	}
+
+	return bw
+}
+
+func (bw *BufferedWriter) run() {
+	for {
+		select {
+		case <-bw.ticker.C:
+			if err := bw.Flush(); err != nil {
+				fmt.Fprintf(bw.errOutput, "failed to flush buffered writer: %+v\n", err)
+			}
+		case <-bw.done:
+			return
+		}
+	}
+}
+
+// Write buffers p, and flushes the buffered data if the size is reached.
+func (bw *BufferedWriter) Write(p []byte) (int, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	return bw.write(p, invalid, false)
+}
+
+// WriteLevel buffers p, and flushes the buffered data if the size is reached
+// or the level is enabled by the flush level.
+func (bw *BufferedWriter) WriteLevel(level Level, p []byte) (int, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	return bw.write(p, level, bw.cfg.FlushLevel.Enabled(level))
+}
+
+// write buffers p with the given level, or invalid if unknown.
+func (bw *BufferedWriter) write(p []byte, level Level, flush bool) (int, error) {
+	if len(bw.buf)+len(p) > bw.cfg.Size {
+		if err := bw.flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	bw.buf = append(bw.buf, p...)
+
+	if bw.lw != nil {
+		bw.addSegment(level)
+	}
+
+	if flush || len(bw.buf) >= bw.cfg.Size {
+		if err := bw.flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the buffered data to the underlying writer.
+func (bw *BufferedWriter) Flush() error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	return bw.flush()
+}
+
+// addSegment extends the last segment up to the end of the buffered data if it has the same level,
+// or adds a new one.
+func (bw *BufferedWriter) addSegment(level Level) {
+	if n := len(bw.segments); n > 0 {
+		if last := &bw.segments[n-1]; last.level == level {
+			last.end = len(bw.buf)
+
+			return
+		}
+	}
+
+	bw.segments = append(bw.segments, bufferedSegment{level: level, end: len(bw.buf)})
+}
+
+func (bw *BufferedWriter) flush() error {
+	if len(bw.buf) == 0 {
+		return nil
+	}
+
+	var err error
+
+	if bw.lw != nil {
+		err = bw.flushSegments()
+	} else {
+		_, err = bw.w.Write(bw.buf)
+	}
+
+	bw.buf = bw.buf[:0]
+	bw.segments = bw.segments[:0]
+
+	return err // nolint:wrapcheck
+}
+
+// flushSegments writes each segment of the buffered data with its level,
+// returning the first error.
+func (bw *BufferedWriter) flushSegments() error {
+	var firstErr error
+
+	start := 0
+
+	for _, segment := range bw.segments {
+		var err error
+
+		if segment.level == invalid {
+			_, err = bw.lw.Write(bw.buf[start:segment.end])
+		} else {
+			_, err = bw.lw.WriteLevel(segment.level, bw.buf[start:segment.end])
+		}
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		start = segment.end
+	}
+
+	return firstErr
+}
+
+// Sync flushes the buffered data, and syncs the underlying writer if it implements Flusher or Syncer.
+func (bw *BufferedWriter) Sync() error {
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	if err := syncOrFlush(bw.w); err != nil && !isSyncUnsupported(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Close stops the periodic flush, flushes the buffered data,
+// and closes the underlying writer if it implements io.Closer, except the standard output and error.
+func (bw *BufferedWriter) Close() error {
+	bw.closeOnce.Do(func() {
+		if bw.ticker != nil {
+			bw.ticker.Stop()
+		}
+
+		close(bw.done)
+	})
+
+	if err := bw.Sync(); err != nil {
+		return err
+	}
+
+	if c, ok := bw.w.(io.Closer); ok && !isStdOutput(bw.w) {
+		return c.Close() // nolint:wrapcheck
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type syncBuffer struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	writes int
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.writes++
+
+	return b.buf.Write(p) // nolint:wrapcheck
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func (b *syncBuffer) Writes() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.writes
+}
+
+func Test_NewBufferedWriter(t *testing.T) {
+	bw := NewBufferedWriter(new(bytes.Buffer), BufferedWriterConfig{FlushInterval: -1})
+	defer bw.Close()
+
+	if bw.cfg.Size != defaultBufferedWriterSize {
+		t.Errorf("Size == %d, want %d", bw.cfg.Size, defaultBufferedWriterSize)
+	}
+
+	if bw.cfg.FlushLevel != defaultBufferedWriterFlushLevel {
+		t.Errorf("FlushLevel == %v, want %v", bw.cfg.FlushLevel, defaultBufferedWriterFlushLevel)
+	}
+
+	if bw.ticker != nil {
+		t.Error("the periodic flush is enabled")
+	}
+}
+
+func TestBufferedWriter_Write(t *testing.T) {
+	output := new(syncBuffer)
+
+	bw := NewBufferedWriter(output, BufferedWriterConfig{Size: 10, FlushInterval: -1})
+	defer bw.Close()
+
+	bw.Write([]byte("12345")) // nolint:errcheck
+
+	if output.Writes() != 0 {
+		t.Errorf("writes == %d, want %d", output.Writes(), 0)
+	}
+
+	bw.Write([]byte("6789")) // nolint:errcheck
+
+	// Exceeds the size, so the previous data is flushed first.
+	bw.Write([]byte("abc")) // nolint:errcheck
+
+	if output.String() != "123456789" {
+		t.Errorf("output == %q, want %q", output.String(), "123456789")
+	}
+
+	// Reaches the size.
+	bw.Write([]byte("defghij")) // nolint:errcheck
+
+	if output.String() != "123456789abcdefghij" {
+		t.Errorf("output == %q, want %q", output.String(), "123456789abcdefghij")
+	}
+}
+
+func TestBufferedWriter_WriteLevel(t *testing.T) {
+	output := new(syncBuffer)
+
+	bw := NewBufferedWriter(output, BufferedWriterConfig{FlushInterval: -1})
+	defer bw.Close()
+
+	bw.WriteLevel(INFO, []byte("info\n"))   // nolint:errcheck
+	bw.WriteLevel(PRINT, []byte("print\n")) // nolint:errcheck
+
+	if output.Writes() != 0 {
+		t.Errorf("writes == %d, want %d", output.Writes(), 0)
+	}
+
+	bw.WriteLevel(ERROR, []byte("error\n")) // nolint:errcheck
+
+	if output.String() != "info\nprint\nerror\n" {
+		t.Errorf("output == %q, want %q", output.String(), "info\nprint\nerror\n")
+	}
+
+	if output.Writes() != 1 {
+		t.Errorf("writes == %d, want %d", output.Writes(), 1)
+	}
+}
+
+func TestBufferedWriter_levelWriter(t *testing.T) {
+	errOutput, infoOutput, filterOutput := new(syncBuffer), new(syncBuffer), new(syncBuffer)
+
+	router := NewLevelRouter(
+		LevelRoute{Filter: ERROR, Writer: errOutput},
+		LevelRoute{Filter: LevelRange{From: WARNING, To: TRACE}, Writer: infoOutput},
+		LevelRoute{Filter: ERROR, Writer: NewLevelFilterWriter(LevelExact(ERROR), filterOutput)},
+	)
+
+	bw := NewBufferedWriter(router, BufferedWriterConfig{FlushInterval: -1, FlushLevel: LevelExact(PANIC)})
+	defer bw.Close()
+
+	bw.WriteLevel(INFO, []byte("info 1\n")) // nolint:errcheck
+	bw.WriteLevel(INFO, []byte("info 2\n")) // nolint:errcheck
+	bw.WriteLevel(ERROR, []byte("error\n")) // nolint:errcheck
+	bw.WriteLevel(FATAL, []byte("fatal\n")) // nolint:errcheck
+	bw.Write([]byte("unknown\n"))           // nolint:errcheck
+	bw.WriteLevel(DEBUG, []byte("debug\n")) // nolint:errcheck
+
+	if err := bw.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := "error\nfatal\nunknown\n"; errOutput.String() != want {
+		t.Errorf("error output == %q, want %q", errOutput.String(), want)
+	}
+
+	if want := "info 1\ninfo 2\nunknown\ndebug\n"; infoOutput.String() != want {
+		t.Errorf("info output == %q, want %q", infoOutput.String(), want)
+	}
+
+	if want := "error\nunknown\n"; filterOutput.String() != want {
+		t.Errorf("filter output == %q, want %q", filterOutput.String(), want)
+	}
+
+	// The consecutive entries with the same level are written at once.
+	if infoOutput.Writes() != 3 {
+		t.Errorf("info writes == %d, want %d", infoOutput.Writes(), 3)
+	}
+
+	if len(bw.segments) != 0 {
+		t.Errorf("segments == %d, want %d", len(bw.segments), 0)
+	}
+}
+
+func TestBufferedWriter_FlushInterval(t *testing.T) {
+	output := new(syncBuffer)
+
+	bw := NewBufferedWriter(output, BufferedWriterConfig{FlushInterval: time.Millisecond})
+	defer bw.Close()
+
+	bw.Write([]byte("hello")) // nolint:errcheck
+
+	deadline := time.Now().Add(time.Second)
+
+	for output.String() != "hello" {
+		if time.Now().After(deadline) {
+			t.Fatal("the buffered data has not been flushed")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBufferedWriter_Close(t *testing.T) {
+	output := &mockFlushSyncer{Writer: new(bytes.Buffer)}
+
+	bw := NewBufferedWriter(output, BufferedWriterConfig{})
+	bw.Write([]byte("hello")) // nolint:errcheck
+
+	if err := bw.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result := output.Writer.(*bytes.Buffer).String(); result != "hello" { // nolint:forcetypeassert
+		t.Errorf("output == %q, want %q", result, "hello")
+	}
+
+	if output.synced != 1 || output.closed != 1 {
+		t.Errorf("output synced/closed == %d/%d, want 1/1", output.synced, output.closed)
+	}
+
+	errClose := errors.New("close error")
+	output.err = errClose
+
+	if err := bw.Close(); !errors.Is(err, errClose) {
+		t.Errorf("error == %v, want %v", err, errClose)
+	}
+}
+
+func TestLogger_BufferedWriter(t *testing.T) {
+	output := new(syncBuffer)
+
+	bw := NewBufferedWriter(output, BufferedWriterConfig{FlushInterval: -1, FlushLevel: LevelExact(ERROR)})
+	defer bw.Close()
+
+	l := New(INFO, bw)
+	l.SetFlags(0)
+	l.exit = func(_ int) {}
+
+	l.Info("hello")
+
+	if output.Writes() != 0 {
+		t.Errorf("writes == %d, want %d", output.Writes(), 0)
+	}
+
+	l.Fatal("bye")
+
+	if want := "INFO - hello\nFATAL - bye\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+
+	func() {
+		defer func() {
+			recover() // nolint:errcheck
+		}()
+
+		l.Panic("panic")
+	}()
+
+	if want := "INFO - hello\nFATAL - bye\nPANIC - panic\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
@@ -0,0 +1,77 @@
+package logger
+
+import "time"
+
+var defaultClock Clock = ClockFunc(time.Now)
+
+// Now calls fn().
+func (fn ClockFunc) Now() time.Time {
+	return fn()
+}
+
+// NewFakeClock creates a new fake clock with the given time.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{t: t}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.RLock()
+	t := c.t
+	c.mu.RUnlock()
+
+	return t
+}
+
+// Set sets the current time of the clock.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.t = t
+	c.mu.Unlock()
+}
+
+// Add advances the current time of the clock by the given duration.
+func (c *FakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+// NewCoarseClock creates a new coarse clock, which updates its time every given resolution.
+//
+// NOTE: Call Stop to release the ticker when the clock is no longer used.
+func NewCoarseClock(resolution time.Duration) *CoarseClock {
+	c := &CoarseClock{
+		ticker: time.NewTicker(resolution),
+		done:   make(chan struct{}),
+	}
+	c.now.Store(time.Now())
+
+	go c.run()
+
+	return c
+}
+
+func (c *CoarseClock) run() {
+	for {
+		select {
+		case t := <-c.ticker.C:
+			c.now.Store(t)
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// Now returns the cached time of the clock.
+func (c *CoarseClock) Now() time.Time {
+	return c.now.Load().(time.Time) // nolint:forcetypeassert
+}
+
+// Stop stops updating the time of the clock.
+func (c *CoarseClock) Stop() {
+	c.stopOnce.Do(func() {
+		c.ticker.Stop()
+		close(c.done)
+	})
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockFunc_Now(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	clock := ClockFunc(func() time.Time { return now })
+
+	if result := clock.Now(); !result.Equal(now) {
+		t.Errorf("Now() == %s, want %s", result, now)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	clock := NewFakeClock(now)
+
+	if result := clock.Now(); !result.Equal(now) {
+		t.Errorf("Now() == %s, want %s", result, now)
+	}
+
+	clock.Add(time.Second)
+
+	if want := now.Add(time.Second); !clock.Now().Equal(want) {
+		t.Errorf("Now() == %s, want %s", clock.Now(), want)
+	}
+
+	clock.Set(now)
+
+	if result := clock.Now(); !result.Equal(now) {
+		t.Errorf("Now() == %s, want %s", result, now)
+	}
+}
+
+func TestCoarseClock(t *testing.T) {
+	start := time.Now()
+
+	clock := NewCoarseClock(time.Millisecond)
+	defer clock.Stop()
+
+	first := clock.Now()
+	if first.Before(start) {
+		t.Errorf("Now() == %s, want after %s", first, start)
+	}
+
+	deadline := time.Now().Add(time.Second)
+
+	for !clock.Now().After(first) {
+		if time.Now().After(deadline) {
+			t.Fatal("the clock has not been updated")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	clock.Stop()
+	clock.Stop() // Must not panic when stopped twice.
+}
+
+func BenchmarkCoarseClock_Now(b *testing.B) {
+	clock := NewCoarseClock(time.Millisecond)
+	defer clock.Stop()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		clock.Now()
+	}
+}
@@ -8,7 +8,7 @@ import (
 func newTestConfig() Config {
 	return Config{
 		Fields: []Field{
-			{"url", `GET "https://example.com"`},
+			{Key: "url", Value: `GET "https://example.com"`},
 		},
 		Datetime:  true,
 		Timestamp: true,
@@ -15,6 +15,10 @@ const (
 	TRACE
 )
 
+// levelSeverityStep is the severity gap between the built-in levels,
+// so the custom levels could be placed between them.
+const levelSeverityStep = 10
+
 // Logger flags.
 const (
 	Ldatetime Flag = 1 << iota
@@ -27,19 +31,48 @@ const (
 	LstdFlags = Ldatetime
 )
 
+// Field types.
+const (
+	fieldTypeAny fieldType = iota
+	fieldTypeString
+	fieldTypeInt64
+	fieldTypeFloat64
+	fieldTypeBool
+	fieldTypeDuration
+	fieldTypeTime
+	fieldTypeBytes
+	fieldTypeStringer
+	fieldTypeObject
+	fieldTypeArray
+	fieldTypeNamespace
+)
+
 // Logger timestamp formats.
 const (
 	TimestampFormatSeconds TimestampFormat = iota + 1
 	TimestampFormatNanoseconds
+	TimestampFormatMilliseconds
+	TimestampFormatMicroseconds
+	TimestampFormatFloatSeconds // seconds with nanoseconds precision, like 1136214245.999999999
 )
 
 const (
 	calldepth    = 4
 	calldepthStd = calldepth + 1
+
+	// calldepthStdLog is added to the logger calldepth, so it also skips the frames of the log package,
+	// like log.Printf and log.(*Logger).output, from the Write method of the writer.
+	calldepthStdLog = 2
 )
 
+// maxCallerDepth is the maximum number of frames inspected to get the caller,
+// skipping the helper functions.
+const maxCallerDepth = 32
+
 const unknownFile = "???"
 
+const hexDigits = "0123456789abcdef"
+
 const (
 	printLevelStr   = ""
 	panicLevelStr   = "PANIC"
@@ -51,8 +84,81 @@ const (
 	traceLevelStr   = "TRACE"
 )
 
+// Level formats.
+const (
+	LevelFormatUpper   LevelFormat = iota + 1 // PANIC, WARNING, INFO...
+	LevelFormatLower                          // panic, warning, info...
+	LevelFormatShort                          // PANC, WARN, INFO...
+	LevelFormatNumeric                        // the severity divided by 10, like 1 (PANIC) or 4.5 between WARNING and INFO
+)
+
+const defaultLevelFormat = LevelFormatUpper
+
+// Logger panic modes.
+const (
+	PanicModeError   PanicMode = iota + 1 // panics with a *PanicError
+	PanicModeMessage                      // panics with the formatted message
+	PanicModeLogger                       // panics with the *Logger, the legacy behaviour
+)
+
+const defaultLevel = INFO
+
+const defaultPanicMode = PanicModeError
+
+const defaultExitTimeout = 5 * time.Second
+
+const (
+	defaultBufferedWriterSize          = 256 * 1024
+	defaultBufferedWriterFlushInterval = time.Second
+)
+
+// NOTE: PRINT is excluded, since its severity is the lowest one.
+var defaultBufferedWriterFlushLevel = LevelRange{From: PANIC, To: ERROR}
+
+const (
+	defaultBacktraceLevel    = TRACE
+	defaultBacktraceSize     = 100
+	defaultBacktraceMaxBytes = 64 * 1024
+)
+
+// NOTE: PRINT is excluded, since its severity is the lowest one.
+var defaultBacktraceFlushLevel = LevelRange{From: PANIC, To: ERROR}
+
+const defaultDedupWindow = time.Second
+
+const (
+	dedupSummaryMessage  = "message repeated %d times"
+	dedupSummaryFieldKey = "repeated"
+)
+
+const (
+	defaultRateLimiterRate            = 100
+	defaultRateLimiterSummaryInterval = 10 * time.Second
+)
+
+const (
+	rateLimiterSummaryMessage  = "%d entries suppressed by the rate limit"
+	rateLimiterSummaryFieldKey = "suppressed"
+)
+
+// Text encoder escape modes.
+const (
+	TextEscapeAll TextEscapeMode = iota + 1
+	TextEscapeMultiline
+	TextEscapeNone
+)
+
 const defaultTextSeparator = " - "
 
+const defaultTextEscapeMode = TextEscapeAll
+
+// JSON encoder key collision policies.
+const (
+	KeyCollisionAddPrefix KeyCollisionPolicy = iota + 1
+	KeyCollisionDrop
+	KeyCollisionAllow
+)
+
 const (
 	defaultJSONFieldKeyDatetime  = "datetime"
 	defaultJSONFieldKeyTimestamp = "timestamp"
@@ -62,6 +168,13 @@ const (
 	defaultJSONFieldKeyMessage   = "message"
 )
 
+const (
+	defaultJSONKeyCollision       = KeyCollisionAddPrefix
+	defaultJSONKeyCollisionPrefix = "fields."
+)
+
 const defaultDatetimeLayout = time.RFC3339
 
 const defaultTimestampFormat = TimestampFormatSeconds
+
+const defaultRedactorReplacement = "[REDACTED]"
@@ -0,0 +1,205 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// NewDedup creates a new dedup processor.
+//
+// NOTE: Add it to the logger with AddProcessor, so the summaries are emitted when the windows close,
+// and call Close to emit the pending summary and stop its timer.
+func NewDedup(cfg DedupConfig) *Dedup {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultDedupWindow
+	}
+
+	if cfg.Summary == nil {
+		cfg.Summary = dedupSummary
+	}
+
+	if cfg.Clock == nil {
+		cfg.Clock = defaultClock
+	}
+
+	return &Dedup{cfg: cfg}
+}
+
+// dedupSummary returns the first entry with the message "message repeated N times"
+// and the field "repeated".
+func dedupSummary(e Entry, repeated int) Entry {
+	e.Message = fmt.Sprintf(dedupSummaryMessage, repeated)
+	e.RawMessage = dedupSummaryMessage
+	e.Args = []interface{}{repeated}
+	e.Fields = append(e.Fields[:len(e.Fields):len(e.Fields)], Int(dedupSummaryFieldKey, repeated))
+
+	return e
+}
+
+// Process processes the entry, emitting the summary with the function set by SetEmit.
+func (d *Dedup) Process(e *Entry) bool {
+	d.mu.Lock()
+	emit := d.emit
+	d.mu.Unlock()
+
+	return d.ProcessEmit(e, emit)
+}
+
+// ProcessEmit drops the entry if it repeats the previous one within the window.
+// Otherwise, emits the summary of the previous one, if repeated, and keeps the entry.
+func (d *Dedup) ProcessEmit(e *Entry, emit EmitFunc) bool {
+	now := d.cfg.Clock.Now()
+
+	d.mu.Lock()
+
+	if d.hasLast && now.Sub(d.since) < d.cfg.Window && d.equal(e) {
+		d.repeated++
+
+		if d.repeated == 1 {
+			gen := d.gen
+			d.timer = time.AfterFunc(d.since.Add(d.cfg.Window).Sub(now), func() {
+				d.expire(gen)
+			})
+		}
+
+		d.mu.Unlock()
+
+		return false
+	}
+
+	summary, ok := d.takeSummary(now)
+
+	d.last = copyEntry(*e)
+	d.hasLast = true
+	d.since = now
+
+	d.mu.Unlock()
+
+	if ok && emit != nil {
+		emit(summary)
+	}
+
+	return true
+}
+
+// SetEmit sets the function to emit the summaries when the windows close.
+func (d *Dedup) SetEmit(emit EmitFunc) {
+	d.mu.Lock()
+	d.emit = emit
+	d.mu.Unlock()
+}
+
+// Flush emits the summary of the repeated entries, if any, without waiting the window to close.
+func (d *Dedup) Flush() error {
+	d.mu.Lock()
+	summary, ok := d.takeSummary(d.cfg.Clock.Now())
+	emit := d.emit
+	d.mu.Unlock()
+
+	if ok && emit != nil {
+		emit(summary)
+	}
+
+	return nil
+}
+
+// Close emits the pending summary, if any, and stops the timer.
+func (d *Dedup) Close() error {
+	return d.Flush()
+}
+
+// expire emits the summary when the window closes,
+// unless it was already emitted by a different entry.
+func (d *Dedup) expire(gen int) {
+	d.mu.Lock()
+
+	if gen != d.gen {
+		d.mu.Unlock()
+
+		return
+	}
+
+	summary, ok := d.takeSummary(d.cfg.Clock.Now())
+	emit := d.emit
+
+	d.mu.Unlock()
+
+	if ok && emit != nil {
+		emit(summary)
+	}
+}
+
+// takeSummary returns the summary of the repeated entries, if any, and resets the state.
+//
+// NOTE: The dedup must be locked.
+func (d *Dedup) takeSummary(now time.Time) (Entry, bool) {
+	var (
+		summary Entry
+		ok      bool
+	)
+
+	if d.repeated > 0 {
+		summary = d.cfg.Summary(d.last, d.repeated)
+
+		if !summary.Time.IsZero() {
+			summary.Time = now.In(summary.Time.Location())
+		}
+
+		ok = true
+	}
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+
+	d.last = Entry{}
+	d.hasLast = false
+	d.repeated = 0
+	d.gen++
+
+	return summary, ok
+}
+
+func (d *Dedup) equal(e *Entry) bool {
+	return d.last.Level == e.Level &&
+		d.last.Message == e.Message &&
+		d.last.RawMessage == e.RawMessage &&
+		fieldsEqual(d.last.Config.Fields, e.Config.Fields) &&
+		fieldsEqual(d.last.Fields, e.Fields)
+}
+
+// copyEntry returns a copy of the entry which doesn't share the fields with it.
+func copyEntry(e Entry) Entry {
+	e.Config.Fields = append([]Field(nil), e.Config.Fields...)
+	e.Fields = append([]Field(nil), e.Fields...)
+	e.Args = append([]interface{}(nil), e.Args...)
+
+	return e
+}
+
+func fieldsEqual(a, b []Field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Key != b[i].Key || a[i].typ != b[i].typ || a[i].integer != b[i].integer || a[i].str != b[i].str ||
+			a[i].loc != b[i].loc {
+			return false
+		}
+
+		// NOTE: The typed values are already compared by their members.
+		switch a[i].typ {
+		case fieldTypeAny, fieldTypeStringer, fieldTypeObject, fieldTypeArray:
+			if !reflect.DeepEqual(a[i].Value, b[i].Value) {
+				return false
+			}
+		case fieldTypeString, fieldTypeInt64, fieldTypeFloat64, fieldTypeBool, fieldTypeDuration, fieldTypeTime,
+			fieldTypeBytes, fieldTypeNamespace:
+		}
+	}
+
+	return true
+}
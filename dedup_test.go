@@ -0,0 +1,363 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestDedupLogger(cfg DedupConfig) (*Logger, *Dedup, *syncBuffer) {
+	output := new(syncBuffer)
+
+	d := NewDedup(cfg)
+
+	l := New(INFO, output)
+	l.SetFlags(0)
+	l.AddProcessor(d)
+
+	return l, d, output
+}
+
+func Test_NewDedup(t *testing.T) {
+	d := NewDedup(DedupConfig{})
+
+	if d.cfg.Window != defaultDedupWindow {
+		t.Errorf("Window == %s, want %s", d.cfg.Window, defaultDedupWindow)
+	}
+
+	if d.cfg.Summary == nil {
+		t.Error("Summary is nil")
+	}
+
+	if d.cfg.Clock == nil {
+		t.Error("Clock is nil")
+	}
+}
+
+func Test_dedupSummary(t *testing.T) {
+	fields := make([]Field, 1, 2)
+	fields[0] = String("foo", "bar")
+
+	e := Entry{Level: ERROR, Message: "failed", Fields: fields}
+
+	summary := dedupSummary(e, 3)
+
+	if summary.Level != ERROR {
+		t.Errorf("Level == %s, want %s", summary.Level, ERROR)
+	}
+
+	if want := "message repeated 3 times"; summary.Message != want {
+		t.Errorf("Message == %q, want %q", summary.Message, want)
+	}
+
+	if len(summary.Fields) != 2 {
+		t.Fatalf("fields == %d, want %d", len(summary.Fields), 2)
+	}
+
+	if field := summary.Fields[1]; field.Key != dedupSummaryFieldKey || field.integer != 3 {
+		t.Errorf("field == %s=%d, want %s=%d", field.Key, field.integer, dedupSummaryFieldKey, 3)
+	}
+
+	if fields[:2][1].Key != "" {
+		t.Error("the entry fields are modified")
+	}
+}
+
+func TestDedup(t *testing.T) { // nolint:funlen
+	type args struct {
+		log func(l *Logger, clock *FakeClock)
+	}
+
+	type want struct {
+		output string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Different",
+			args: args{
+				log: func(l *Logger, _ *FakeClock) {
+					l.Info("foo")
+					l.Info("bar")
+					l.Error("bar")
+					l.WithFields(Int("n", 1)).Error("bar")
+					l.WithFields(Int("n", 2)).Error("bar")
+				},
+			},
+			want: want{
+				output: "INFO - foo\nINFO - bar\nERROR - bar\nERROR - n=1 - bar\nERROR - n=2 - bar\n",
+			},
+		},
+		{
+			name: "Repeated",
+			args: args{
+				log: func(l *Logger, _ *FakeClock) {
+					l.Error("failed")
+					l.Error("failed")
+					l.Error("failed")
+					l.Errorf("failed")
+					l.Info("done")
+				},
+			},
+			want: want{
+				output: "ERROR - failed\nERROR - repeated=2 - message repeated 2 times\n" +
+					"ERROR - failed\nINFO - done\n",
+			},
+		},
+		{
+			name: "RepeatedWithFields",
+			args: args{
+				log: func(l *Logger, _ *FakeClock) {
+					l2 := l.WithFields(String("ip", "127.0.0.1"))
+
+					l2.Error("failed")
+					l2.Error("failed")
+					l2.Error("failed")
+					l.Error("failed")
+				},
+			},
+			want: want{
+				output: "ERROR - ip=127.0.0.1 - failed\n" +
+					"ERROR - ip=127.0.0.1 - repeated=2 - message repeated 2 times\n" +
+					"ERROR - failed\n",
+			},
+		},
+		{
+			name: "RepeatedWithLazyFields",
+			args: args{
+				log: func(l *Logger, _ *FakeClock) {
+					l2 := l.WithFields(Lazy("ip", func() interface{} { return "127.0.0.1" }))
+
+					for i := 0; i < 3; i++ {
+						l2.LogFields(ERROR, "failed", Lazy("n", func() interface{} { return 1 }))
+					}
+
+					l.Error("failed")
+				},
+			},
+			want: want{
+				output: "ERROR - ip=127.0.0.1 - n=1 - failed\n" +
+					"ERROR - ip=127.0.0.1 - n=1 - repeated=2 - message repeated 2 times\n" +
+					"ERROR - failed\n",
+			},
+		},
+		{
+			name: "WindowExpired",
+			args: args{
+				log: func(l *Logger, clock *FakeClock) {
+					l.Error("failed")
+					l.Error("failed")
+					clock.Add(time.Hour)
+					l.Error("failed")
+					l.Error("failed")
+				},
+			},
+			want: want{
+				output: "ERROR - failed\nERROR - repeated=1 - message repeated 1 times\nERROR - failed\n",
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			clock := NewFakeClock(time.Now())
+
+			l, d, output := newTestDedupLogger(DedupConfig{Window: time.Hour, Clock: clock})
+			defer d.Close()
+
+			test.args.log(l, clock)
+
+			if output.String() != test.want.output {
+				t.Errorf("output == %q, want %q", output.String(), test.want.output)
+			}
+		})
+	}
+}
+
+func TestDedup_Expire(t *testing.T) {
+	l, d, output := newTestDedupLogger(DedupConfig{Window: 20 * time.Millisecond})
+	defer d.Close()
+
+	l.Error("failed")
+	l.Error("failed")
+	l.Error("failed")
+
+	want := "ERROR - failed\nERROR - repeated=2 - message repeated 2 times\n"
+
+	for i := 0; i < 100 && output.String() != want; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if output.String() != want {
+		t.Fatalf("output == %q, want %q", output.String(), want)
+	}
+
+	// The window is closed, so it's logged again.
+	l.Error("failed")
+
+	want += "ERROR - failed\n"
+
+	if output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestDedup_Flush(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	d := NewDedup(DedupConfig{Window: time.Hour})
+
+	l := New(INFO, output)
+	l.SetFlags(0)
+	l.AddProcessor(d)
+
+	l.Warning("slow")
+	l.Warning("slow")
+
+	if err := l.Sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "WARNING - slow\nWARNING - repeated=1 - message repeated 1 times\n"
+
+	if output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+
+	// Nothing pending.
+	if err := l.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+
+	if d.timer != nil {
+		t.Error("the timer is not stopped")
+	}
+}
+
+func TestDedup_Process(t *testing.T) {
+	var emitted []Entry
+
+	d := NewDedup(DedupConfig{Window: time.Hour})
+	d.SetEmit(func(e Entry) {
+		emitted = append(emitted, e)
+	})
+
+	entries := []Entry{
+		{Level: INFO, Message: "foo"},
+		{Level: INFO, Message: "foo"},
+		{Level: INFO, Message: "bar"},
+	}
+
+	results := make([]string, 0)
+
+	for i := range entries {
+		if d.Process(&entries[i]) {
+			results = append(results, entries[i].Message)
+		}
+	}
+
+	if got := strings.Join(results, ","); got != "foo,bar" {
+		t.Errorf("processed == %q, want %q", got, "foo,bar")
+	}
+
+	if len(emitted) != 1 {
+		t.Fatalf("emitted == %d, want %d", len(emitted), 1)
+	}
+
+	if want := "message repeated 1 times"; emitted[0].Message != want {
+		t.Errorf("Message == %q, want %q", emitted[0].Message, want)
+	}
+
+	d.Close() // nolint:errcheck
+}
+
+func Test_fieldsEqual(t *testing.T) {
+	type args struct {
+		a, b []Field
+	}
+
+	type want struct {
+		result bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Empty",
+			args: args{},
+			want: want{result: true},
+		},
+		{
+			name: "Equal",
+			args: args{
+				a: []Field{String("foo", "bar"), Int("n", 1), Any("list", []int{1, 2})},
+				b: []Field{String("foo", "bar"), Int("n", 1), Any("list", []int{1, 2})},
+			},
+			want: want{result: true},
+		},
+		{
+			name: "DifferentLength",
+			args: args{
+				a: []Field{String("foo", "bar")},
+				b: []Field{String("foo", "bar"), Int("n", 1)},
+			},
+			want: want{result: false},
+		},
+		{
+			name: "DifferentKey",
+			args: args{
+				a: []Field{Int("n", 1)},
+				b: []Field{Int("m", 1)},
+			},
+			want: want{result: false},
+		},
+		{
+			name: "DifferentValue",
+			args: args{
+				a: []Field{Int("n", 1)},
+				b: []Field{Int("n", 2)},
+			},
+			want: want{result: false},
+		},
+		{
+			name: "DifferentAnyValue",
+			args: args{
+				a: []Field{Any("list", []int{1, 2})},
+				b: []Field{Any("list", []int{1, 3})},
+			},
+			want: want{result: false},
+		},
+		{
+			name: "DifferentLocation",
+			args: args{
+				a: []Field{Time("t", time.Unix(0, 0).UTC())},
+				b: []Field{Time("t", time.Unix(0, 0).In(time.FixedZone("CET", 3600)))},
+			},
+			want: want{result: false},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			if result := fieldsEqual(test.args.a, test.args.b); result != test.want.result {
+				t.Errorf("result == %t, want %t", result, test.want.result)
+			}
+		})
+	}
+}
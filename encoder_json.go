@@ -1,8 +1,6 @@
 package logger
 
-import (
-	"github.com/savsgio/gotils/strings"
-)
+import "time"
 
 // NewEncoderJSON creates a new json encoder.
 func NewEncoderJSON(cfg EncoderJSONConfig) *EncoderJSON {
@@ -38,6 +36,18 @@ func NewEncoderJSON(cfg EncoderJSONConfig) *EncoderJSON {
 		cfg.TimestampFormat = defaultTimestampFormat
 	}
 
+	if cfg.KeyCollision == 0 {
+		cfg.KeyCollision = defaultJSONKeyCollision
+	}
+
+	if cfg.KeyCollisionPrefix == "" {
+		cfg.KeyCollisionPrefix = defaultJSONKeyCollisionPrefix
+	}
+
+	if cfg.LevelFormat == 0 {
+		cfg.LevelFormat = defaultLevelFormat
+	}
+
 	enc := new(EncoderJSON)
 	enc.cfg = cfg
 
@@ -48,67 +58,166 @@ func NewEncoderJSON(cfg EncoderJSONConfig) *EncoderJSON {
 func (enc *EncoderJSON) Copy() Encoder {
 	copyEnc := NewEncoderJSON(enc.cfg)
 	copyEnc.EncoderBase = *enc.EncoderBase.Copy()
+	copyEnc.namespaces = enc.namespaces
 
 	return copyEnc
 }
 
-func (enc *EncoderJSON) keys(cfg Config) (keys []string) {
-	if cfg.Datetime {
-		keys = append(keys, enc.cfg.FieldMap.DatetimeKey)
+func (enc *EncoderJSON) isReservedKey(cfg Config, key string) bool {
+	switch key {
+	case enc.cfg.FieldMap.DatetimeKey:
+		return cfg.Datetime
+	case enc.cfg.FieldMap.TimestampKey:
+		return cfg.Timestamp
+	case enc.cfg.FieldMap.FileKey:
+		return cfg.Shortfile || cfg.Longfile
+	case enc.cfg.FieldMap.FunctionKey:
+		return cfg.Function
+	case enc.cfg.FieldMap.LevelKey, enc.cfg.FieldMap.MessageKey:
+		return true
+	default:
+		return false
 	}
+}
+
+func (enc *EncoderJSON) writeKey(buf *Buffer, prefix, key string) {
+	buf.WriteByte('"')      // nolint:errcheck
+	buf.WriteString(prefix) // nolint:errcheck
 
-	if cfg.Timestamp {
-		keys = append(keys, enc.cfg.FieldMap.TimestampKey)
+	n := buf.Len()
+	buf.WriteString(key) // nolint:errcheck
+	buf.Escape(n)
+
+	buf.WriteString("\":") // nolint:errcheck
+}
+
+// writeFields writes the given fields, returning the number of opened namespaces.
+//
+// The key collisions are only checked for the fields out of a namespace.
+// The colliding namespaces are prefixed instead of dropped, so their fields are kept nested.
+func (enc *EncoderJSON) writeFields(buf *Buffer, cfg Config, fields []Field, nested bool) int {
+	namespaces := 0
+
+	for _, field := range fields {
+		prefix := ""
+
+		if !nested && enc.isReservedKey(cfg, field.Key) {
+			switch enc.cfg.KeyCollision {
+			case KeyCollisionAddPrefix:
+				prefix = enc.cfg.KeyCollisionPrefix
+			case KeyCollisionDrop:
+				if field.typ != fieldTypeNamespace {
+					continue
+				}
+
+				prefix = enc.cfg.KeyCollisionPrefix
+			case KeyCollisionAllow:
+			}
+		}
+
+		enc.writeKey(buf, prefix, field.Key)
+
+		if field.typ == fieldTypeNamespace {
+			buf.WriteByte('{') // nolint:errcheck
+
+			nested = true
+			namespaces++
+
+			continue
+		}
+
+		enc.writeFieldValue(buf, field)
+		buf.WriteByte(',') // nolint:errcheck
 	}
 
-	keys = append(keys, enc.cfg.FieldMap.LevelKey)
+	return namespaces
+}
+
+func (enc *EncoderJSON) writeFieldValue(buf *Buffer, field Field) {
+	switch field.marshalerType() {
+	case fieldTypeObject:
+		enc.writeObject(buf, field.Value.(ObjectMarshaler)) // nolint:forcetypeassert
+
+		return
+	case fieldTypeArray:
+		enc.writeArray(buf, field.Value.(ArrayMarshaler)) // nolint:forcetypeassert
 
-	if cfg.Shortfile || cfg.Longfile {
-		keys = append(keys, enc.cfg.FieldMap.FileKey)
+		return
 	}
 
-	if cfg.Function {
-		keys = append(keys, enc.cfg.FieldMap.FunctionKey)
+	if !field.isJSONQuoted() {
+		buf.WriteFieldValue(field, enc.cfg.DatetimeLayout)
+
+		return
 	}
 
-	keys = append(keys, enc.cfg.FieldMap.MessageKey)
+	buf.WriteByte('"') // nolint:errcheck
+
+	n := buf.Len()
+	buf.WriteFieldValue(field, enc.cfg.DatetimeLayout)
+	buf.Escape(n)
 
-	return keys
+	buf.WriteByte('"') // nolint:errcheck
 }
 
-// Configure configures then encoder.
-//
-// - Encondes and sets the fields.
-func (enc *EncoderJSON) Configure(cfg Config) {
-	if len(cfg.Fields) == 0 {
-		enc.SetFieldsEncoded("")
+func (enc *EncoderJSON) writeMarshalerError(buf *Buffer, startAt int, err error) {
+	buf.truncate(startAt)
+	enc.writeFieldValue(buf, stringField("", err.Error()))
+}
+
+func (enc *EncoderJSON) writeObject(buf *Buffer, value ObjectMarshaler) {
+	if isNilValue(value) {
+		buf.WriteString("null") // nolint:errcheck
 
 		return
 	}
 
-	buf := AcquireBuffer()
-	keys := enc.keys(cfg)
+	n := buf.Len()
+	buf.WriteByte('{') // nolint:errcheck
 
-	for _, field := range cfg.Fields {
-		buf.WriteString("\"") // nolint:errcheck
+	if err := value.MarshalLogObject(jsonObjectEncoder{enc: enc, buf: buf}); err != nil {
+		enc.writeMarshalerError(buf, n, err)
 
-		if strings.Include(keys, field.Key) {
-			buf.WriteString("fields.") // nolint:errcheck
-		}
+		return
+	}
 
-		n := buf.Len()
-		buf.WriteString(field.Key) // nolint:errcheck
-		buf.Escape(n)
+	buf.closeJSON('}')
+}
 
-		buf.WriteString("\":\"") // nolint:errcheck
+func (enc *EncoderJSON) writeArray(buf *Buffer, value ArrayMarshaler) {
+	if isNilValue(value) {
+		buf.WriteString("null") // nolint:errcheck
 
-		n = buf.Len()
-		buf.WriteInterface(field.Value)
-		buf.Escape(n)
+		return
+	}
 
-		buf.WriteString("\",") // nolint:errcheck
+	n := buf.Len()
+	buf.WriteByte('[') // nolint:errcheck
+
+	if err := value.MarshalLogArray(jsonArrayEncoder{enc: enc, buf: buf}); err != nil {
+		enc.writeMarshalerError(buf, n, err)
+
+		return
 	}
 
+	buf.closeJSON(']')
+}
+
+// Configure configures then encoder.
+//
+// - Encondes and sets the fields.
+//
+// NOTE: The fields with lazy values are encoded with each entry.
+func (enc *EncoderJSON) Configure(cfg Config) {
+	if len(cfg.Fields) == 0 || cfg.lazy {
+		enc.SetFieldsEncoded("")
+		enc.namespaces = 0
+
+		return
+	}
+
+	buf := AcquireBuffer()
+	enc.namespaces = enc.writeFields(buf, cfg, cfg.Fields, false)
 	enc.SetFieldsEncoded(buf.String())
 
 	ReleaseBuffer(buf)
@@ -129,24 +238,31 @@ func (enc *EncoderJSON) Encode(buf *Buffer, e Entry) error { // nolint:funlen
 	if e.Config.Timestamp {
 		buf.WriteString("\"")                          // nolint:errcheck
 		buf.WriteString(enc.cfg.FieldMap.TimestampKey) // nolint:errcheck
-		buf.WriteString("\":\"")                       // nolint:errcheck
+		buf.WriteString("\":")                         // nolint:errcheck
 		buf.WriteTimestamp(e.Time, enc.cfg.TimestampFormat)
-		buf.WriteString("\",") // nolint:errcheck
+		buf.WriteByte(',') // nolint:errcheck
 	}
 
-	if levelStr := e.Level.String(); levelStr != "" {
+	if levelStr, numeric := e.Level.format(enc.cfg.LevelFormat, enc.cfg.LevelNames, enc.cfg.PrintLevel); levelStr != "" {
 		buf.WriteString("\"")                      // nolint:errcheck
 		buf.WriteString(enc.cfg.FieldMap.LevelKey) // nolint:errcheck
-		buf.WriteString("\":\"")                   // nolint:errcheck
-		buf.WriteString(levelStr)                  // nolint:errcheck
-		buf.WriteString("\",")                     // nolint:errcheck
+
+		if numeric {
+			buf.WriteString("\":")    // nolint:errcheck
+			buf.WriteString(levelStr) // nolint:errcheck
+			buf.WriteByte(',')        // nolint:errcheck
+		} else {
+			buf.WriteString("\":\"")  // nolint:errcheck
+			buf.WriteString(levelStr) // nolint:errcheck
+			buf.WriteString("\",")    // nolint:errcheck
+		}
 	}
 
 	if e.Config.Shortfile || e.Config.Longfile {
 		buf.WriteString("\"")                     // nolint:errcheck
 		buf.WriteString(enc.cfg.FieldMap.FileKey) // nolint:errcheck
 		buf.WriteString("\":\"")                  // nolint:errcheck
-		buf.WriteFileCaller(e.Caller, e.Config.Shortfile)
+		buf.writeEntryFileCaller(&e)
 		buf.WriteString("\",") // nolint:errcheck
 	}
 
@@ -158,7 +274,23 @@ func (enc *EncoderJSON) Encode(buf *Buffer, e Entry) error { // nolint:funlen
 		buf.WriteString("\",")                        // nolint:errcheck
 	}
 
-	buf.WriteString(enc.FieldsEncoded())         // nolint:errcheck
+	namespaces := enc.namespaces
+
+	if e.Config.lazy {
+		namespaces = enc.writeFields(buf, e.Config, e.Config.Fields, false)
+	} else {
+		buf.WriteString(enc.FieldsEncoded()) // nolint:errcheck
+	}
+
+	if len(e.Fields) > 0 {
+		namespaces += enc.writeFields(buf, e.Config, e.Fields, namespaces > 0)
+	}
+
+	for i := 0; i < namespaces; i++ {
+		buf.closeJSON('}')
+		buf.WriteByte(',') // nolint:errcheck
+	}
+
 	buf.WriteString("\"")                        // nolint:errcheck
 	buf.WriteString(enc.cfg.FieldMap.MessageKey) // nolint:errcheck
 	buf.WriteString("\":\"")                     // nolint:errcheck
@@ -172,3 +304,104 @@ func (enc *EncoderJSON) Encode(buf *Buffer, e Entry) error { // nolint:funlen
 
 	return nil
 }
+
+func (oe jsonObjectEncoder) addField(field Field) {
+	oe.enc.writeKey(oe.buf, "", field.Key)
+	oe.enc.writeFieldValue(oe.buf, field)
+	oe.buf.WriteByte(',') // nolint:errcheck
+}
+
+// AddString adds a string value.
+func (oe jsonObjectEncoder) AddString(key, value string) {
+	oe.addField(stringField(key, value))
+}
+
+// AddInt64 adds an int64 value.
+func (oe jsonObjectEncoder) AddInt64(key string, value int64) {
+	oe.addField(int64Field(key, value))
+}
+
+// AddFloat64 adds a float64 value.
+func (oe jsonObjectEncoder) AddFloat64(key string, value float64) {
+	oe.addField(float64Field(key, value))
+}
+
+// AddBool adds a bool value.
+func (oe jsonObjectEncoder) AddBool(key string, value bool) {
+	oe.addField(boolField(key, value))
+}
+
+// AddDuration adds a duration value.
+func (oe jsonObjectEncoder) AddDuration(key string, value time.Duration) {
+	oe.addField(durationField(key, value))
+}
+
+// AddTime adds a time value.
+func (oe jsonObjectEncoder) AddTime(key string, value time.Time) {
+	oe.addField(timeField(key, value))
+}
+
+// AddObject adds an object value.
+func (oe jsonObjectEncoder) AddObject(key string, value ObjectMarshaler) {
+	oe.addField(Object(key, value))
+}
+
+// AddArray adds an array value.
+func (oe jsonObjectEncoder) AddArray(key string, value ArrayMarshaler) {
+	oe.addField(Array(key, value))
+}
+
+// AddAny adds a value of any type.
+func (oe jsonObjectEncoder) AddAny(key string, value interface{}) {
+	oe.addField(Any(key, value))
+}
+
+func (ae jsonArrayEncoder) appendField(field Field) {
+	ae.enc.writeFieldValue(ae.buf, field)
+	ae.buf.WriteByte(',') // nolint:errcheck
+}
+
+// AppendString appends a string value.
+func (ae jsonArrayEncoder) AppendString(value string) {
+	ae.appendField(stringField("", value))
+}
+
+// AppendInt64 appends an int64 value.
+func (ae jsonArrayEncoder) AppendInt64(value int64) {
+	ae.appendField(int64Field("", value))
+}
+
+// AppendFloat64 appends a float64 value.
+func (ae jsonArrayEncoder) AppendFloat64(value float64) {
+	ae.appendField(float64Field("", value))
+}
+
+// AppendBool appends a bool value.
+func (ae jsonArrayEncoder) AppendBool(value bool) {
+	ae.appendField(boolField("", value))
+}
+
+// AppendDuration appends a duration value.
+func (ae jsonArrayEncoder) AppendDuration(value time.Duration) {
+	ae.appendField(durationField("", value))
+}
+
+// AppendTime appends a time value.
+func (ae jsonArrayEncoder) AppendTime(value time.Time) {
+	ae.appendField(timeField("", value))
+}
+
+// AppendObject appends an object value.
+func (ae jsonArrayEncoder) AppendObject(value ObjectMarshaler) {
+	ae.appendField(Object("", value))
+}
+
+// AppendArray appends an array value.
+func (ae jsonArrayEncoder) AppendArray(value ArrayMarshaler) {
+	ae.appendField(Array("", value))
+}
+
+// AppendAny appends a value of any type.
+func (ae jsonArrayEncoder) AppendAny(value interface{}) {
+	ae.appendField(Any("", value))
+}
@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -43,8 +45,11 @@ func Test_NewEncoderJSON(t *testing.T) { // nolint:funlen
 						FunctionKey:  defaultJSONFieldKeyFunction,
 						MessageKey:   defaultJSONFieldKeyMessage,
 					},
-					DatetimeLayout:  defaultDatetimeLayout,
-					TimestampFormat: defaultTimestampFormat,
+					DatetimeLayout:     defaultDatetimeLayout,
+					TimestampFormat:    defaultTimestampFormat,
+					KeyCollision:       defaultJSONKeyCollision,
+					KeyCollisionPrefix: defaultJSONKeyCollisionPrefix,
+					LevelFormat:        defaultLevelFormat,
 				},
 			},
 		},
@@ -59,8 +64,11 @@ func Test_NewEncoderJSON(t *testing.T) { // nolint:funlen
 						FunctionKey:  "caller.func",
 						MessageKey:   "msg",
 					},
-					DatetimeLayout:  time.RFC1123,
-					TimestampFormat: TimestampFormatNanoseconds,
+					DatetimeLayout:     time.RFC1123,
+					TimestampFormat:    TimestampFormatNanoseconds,
+					KeyCollision:       KeyCollisionDrop,
+					KeyCollisionPrefix: "@",
+					LevelFormat:        LevelFormatNumeric,
 				},
 			},
 			want: want{
@@ -73,8 +81,11 @@ func Test_NewEncoderJSON(t *testing.T) { // nolint:funlen
 						FunctionKey:  "caller.func",
 						MessageKey:   "msg",
 					},
-					DatetimeLayout:  time.RFC1123,
-					TimestampFormat: TimestampFormatNanoseconds,
+					DatetimeLayout:     time.RFC1123,
+					TimestampFormat:    TimestampFormatNanoseconds,
+					KeyCollision:       KeyCollisionDrop,
+					KeyCollisionPrefix: "@",
+					LevelFormat:        LevelFormatNumeric,
 				},
 			},
 		},
@@ -114,7 +125,7 @@ func TestEncoderJSON_Copy(t *testing.T) {
 	testEncoderBaseCopy(t, &enc.EncoderBase, &copyEnc.EncoderBase)
 }
 
-func TestEncoderJSON_keys(t *testing.T) {
+func TestEncoderJSON_isReservedKey(t *testing.T) { // nolint:funlen
 	type args struct {
 		cfg Config
 	}
@@ -164,11 +175,27 @@ func TestEncoderJSON_keys(t *testing.T) {
 
 	enc := newTestEncoderJSON()
 
+	allKeys := []string{
+		defaultJSONFieldKeyDatetime,
+		defaultJSONFieldKeyTimestamp,
+		defaultJSONFieldKeyLevel,
+		defaultJSONFieldKeyFile,
+		defaultJSONFieldKeyFunction,
+		defaultJSONFieldKeyMessage,
+		"foo",
+	}
+
 	for i := range tests {
 		test := tests[i]
 
 		t.Run("", func(t *testing.T) {
-			result := enc.keys(test.args.cfg)
+			result := make([]string, 0)
+
+			for _, key := range allKeys {
+				if enc.isReservedKey(test.args.cfg, key) {
+					result = append(result, key)
+				}
+			}
 
 			if !reflect.DeepEqual(result, test.want.result) {
 				t.Errorf("keys == %v, want %v", result, test.want.result)
@@ -204,7 +231,7 @@ func TestEncoderJSON_Configure(t *testing.T) { // nolint:funlen
 			args: args{
 				cfg: Config{
 					Fields: []Field{
-						{enc.cfg.FieldMap.DatetimeKey, "hello"}, {"foo", "bar"}, {"buzz", []int{1, 2, 3}},
+						{Key: enc.cfg.FieldMap.DatetimeKey, Value: "hello"}, {Key: "foo", Value: "bar"}, {Key: "buzz", Value: []int{1, 2, 3}},
 					},
 					Datetime: false,
 				},
@@ -217,7 +244,7 @@ func TestEncoderJSON_Configure(t *testing.T) { // nolint:funlen
 			args: args{
 				cfg: Config{
 					Fields: []Field{
-						{enc.cfg.FieldMap.DatetimeKey, "hello"}, {"foo", "bar"}, {"buzz", []int{1, 2, 3}},
+						{Key: enc.cfg.FieldMap.DatetimeKey, Value: "hello"}, {Key: "foo", Value: "bar"}, {Key: "buzz", Value: []int{1, 2, 3}},
 					},
 					Datetime: true,
 				},
@@ -229,7 +256,7 @@ func TestEncoderJSON_Configure(t *testing.T) { // nolint:funlen
 		{
 			args: args{
 				cfg: Config{
-					Fields: []Field{{"foo", `id: "123"`}, {"buzz", []int{1, 2, 3}}},
+					Fields: []Field{{Key: "foo", Value: `id: "123"`}, {Key: "buzz", Value: []int{1, 2, 3}}},
 				},
 			},
 			want: want{
@@ -239,13 +266,53 @@ func TestEncoderJSON_Configure(t *testing.T) { // nolint:funlen
 		{
 			args: args{
 				cfg: Config{
-					Fields: []Field{{`foo"ter"`, `id: "123"`}, {"buzz", []int{1, 2, 3}}},
+					Fields: []Field{{Key: `foo"ter"`, Value: `id: "123"`}, {Key: "buzz", Value: []int{1, 2, 3}}},
 				},
 			},
 			want: want{
 				fieldsEncoded: `"foo\"ter\"":"id: \"123\"","buzz":"[1 2 3]",`,
 			},
 		},
+		{
+			args: args{
+				cfg: Config{
+					Fields: []Field{
+						String("s", `a "b"`), Int64("i", -1), Float64("f", 1.5), Float64("nan", math.NaN()),
+						Bool("b", true), Duration("d", time.Second), Time("t", time.Unix(0, 0).UTC()),
+					},
+				},
+			},
+			want: want{
+				fieldsEncoded: `"s":"a \"b\"","i":-1,"f":1.5,"nan":"NaN","b":true,"d":"1s","t":"1970-01-01T00:00:00Z",`,
+			},
+		},
+		{
+			args: args{
+				cfg: Config{
+					Fields: []Field{
+						Object("user", newTestObject()), Any("roles", testArray{"a", 1}), Array("empty", testArray{}),
+						Object("invalid", testObject{id: 4, err: errors.New("failed")}),
+					},
+				},
+			},
+			want: want{
+				fieldsEncoded: `"user":{"id":1,"name":"savsgio","roles":["admin",{"id":2,"name":"dev"}],` +
+					`"address":{"id":3,"name":"street \"1\""}},"roles":["a","1"],"empty":[],"invalid":"failed",`,
+			},
+		},
+		{
+			args: args{
+				cfg: Config{
+					Fields: []Field{
+						Any("user", (*testObject)(nil)), Object("address", (*testObject)(nil)),
+						Array("roles", testArray(nil)), Array("users", testArray{(*testObject)(nil)}),
+					},
+				},
+			},
+			want: want{
+				fieldsEncoded: `"user":null,"address":null,"roles":null,"users":[null],`,
+			},
+		},
 	}
 
 	for i := range tests {
@@ -263,6 +330,280 @@ func TestEncoderJSON_Configure(t *testing.T) { // nolint:funlen
 	}
 }
 
+func TestEncoderJSON_Configure_keyCollision(t *testing.T) { // nolint:funlen
+	type args struct {
+		keyCollision       KeyCollisionPolicy
+		keyCollisionPrefix string
+	}
+
+	type want struct {
+		fieldsEncoded string
+	}
+
+	cfg := Config{
+		Fields: []Field{
+			String("level", "hello"), String("foo", "bar"), Namespace("http"), String("message", "world"),
+		},
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "prefix",
+			args: args{
+				keyCollision:       KeyCollisionAddPrefix,
+				keyCollisionPrefix: "@",
+			},
+			want: want{
+				fieldsEncoded: `"@level":"hello","foo":"bar","http":{"message":"world",`,
+			},
+		},
+		{
+			name: "drop",
+			args: args{
+				keyCollision: KeyCollisionDrop,
+			},
+			want: want{
+				fieldsEncoded: `"foo":"bar","http":{"message":"world",`,
+			},
+		},
+		{
+			name: "allow",
+			args: args{
+				keyCollision: KeyCollisionAllow,
+			},
+			want: want{
+				fieldsEncoded: `"level":"hello","foo":"bar","http":{"message":"world",`,
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			enc := NewEncoderJSON(EncoderJSONConfig{
+				KeyCollision:       test.args.keyCollision,
+				KeyCollisionPrefix: test.args.keyCollisionPrefix,
+			})
+			enc.Configure(cfg)
+
+			if fieldsEncoded := enc.FieldsEncoded(); fieldsEncoded != test.want.fieldsEncoded {
+				t.Errorf("fieldsEncoded == %s, want %s", fieldsEncoded, test.want.fieldsEncoded)
+			}
+
+			if enc.namespaces != 1 {
+				t.Errorf("namespaces == %d, want %d", enc.namespaces, 1)
+			}
+		})
+	}
+}
+
+func TestEncoderJSON_Encode_namespaces(t *testing.T) { // nolint:funlen
+	type args struct {
+		keyCollision KeyCollisionPolicy
+		cfgFields    []Field
+		fields       []Field
+	}
+
+	type want struct {
+		result string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "config",
+			args: args{
+				cfgFields: []Field{String("id", "1"), Namespace("http"), String("method", "GET")},
+				fields:    []Field{Int("status", 200)},
+			},
+			want: want{
+				result: `{"level":"INFO","id":"1","http":{"method":"GET","status":200},"message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "entry",
+			args: args{
+				cfgFields: []Field{String("id", "1")},
+				fields:    []Field{Namespace("http"), Namespace("req"), Int("status", 200)},
+			},
+			want: want{
+				result: `{"level":"INFO","id":"1","http":{"req":{"status":200}},"message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				cfgFields: []Field{Namespace("http")},
+			},
+			want: want{
+				result: `{"level":"INFO","http":{},"message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "collisionDropConfig",
+			args: args{
+				keyCollision: KeyCollisionDrop,
+				cfgFields:    []Field{Namespace("message"), String("level", "x")},
+				fields:       []Field{Int("status", 200)},
+			},
+			want: want{
+				result: `{"level":"INFO","fields.message":{"level":"x","status":200},"message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "collisionDropEntry",
+			args: args{
+				keyCollision: KeyCollisionDrop,
+				fields:       []Field{String("level", "x"), Namespace("level"), String("message", "y")},
+			},
+			want: want{
+				result: `{"level":"INFO","fields.level":{"message":"y"},"message":"hello"}` + "\n",
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			buf := AcquireBuffer()
+			defer ReleaseBuffer(buf)
+
+			cfg := Config{Fields: test.args.cfgFields}
+
+			enc := NewEncoderJSON(EncoderJSONConfig{KeyCollision: test.args.keyCollision})
+			enc.Configure(cfg)
+
+			e := Entry{
+				Config:  cfg,
+				Level:   INFO,
+				Message: "hello",
+				Fields:  test.args.fields,
+			}
+
+			if err := enc.Copy().Encode(buf, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result := buf.String(); result != test.want.result {
+				t.Errorf("result == %s, want %s", result, test.want.result)
+			}
+		})
+	}
+}
+
+func TestEncoderJSON_Encode_level(t *testing.T) { // nolint:funlen
+	type args struct {
+		cfg   EncoderJSONConfig
+		level Level
+	}
+
+	type want struct {
+		result string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Upper",
+			args: args{
+				level: WARNING,
+			},
+			want: want{
+				result: `{"level":"WARNING","message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "Lower",
+			args: args{
+				cfg:   EncoderJSONConfig{LevelFormat: LevelFormatLower},
+				level: WARNING,
+			},
+			want: want{
+				result: `{"level":"warning","message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "Numeric",
+			args: args{
+				cfg:   EncoderJSONConfig{LevelFormat: LevelFormatNumeric},
+				level: WARNING,
+			},
+			want: want{
+				result: `{"level":4,"message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "LevelNames",
+			args: args{
+				cfg: EncoderJSONConfig{
+					LevelFormat: LevelFormatNumeric,
+					LevelNames:  map[Level]string{WARNING: "warn"},
+				},
+				level: WARNING,
+			},
+			want: want{
+				result: `{"level":"warn","message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "Print",
+			args: args{
+				level: PRINT,
+			},
+			want: want{
+				result: `{"message":"hello"}` + "\n",
+			},
+		},
+		{
+			name: "PrintLevel",
+			args: args{
+				cfg:   EncoderJSONConfig{LevelFormat: LevelFormatLower, PrintLevel: true},
+				level: PRINT,
+			},
+			want: want{
+				result: `{"level":"print","message":"hello"}` + "\n",
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			buf := AcquireBuffer()
+			defer ReleaseBuffer(buf)
+
+			enc := NewEncoderJSON(test.args.cfg)
+			enc.Configure(Config{})
+
+			e := Entry{
+				Level:   test.args.level,
+				Message: "hello",
+			}
+
+			if err := enc.Encode(buf, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result := buf.String(); result != test.want.result {
+				t.Errorf("result == %s, want %s", result, test.want.result)
+			}
+		})
+	}
+}
+
 func TestEncoderJSON_Encode(t *testing.T) { // nolint:funlen,dupl
 	testCases := []testEncodeCase{
 		{
@@ -295,7 +636,7 @@ func TestEncoderJSON_Encode(t *testing.T) { // nolint:funlen,dupl
 			},
 			want: testEncodeWant{
 				lineRegexExpr: fmt.Sprintf(
-					`^{"datetime":"%s","timestamp":"%s","level":"%s","file":"%s","func":"%s","message":"%s"}\n$`,
+					`^{"datetime":"%s","timestamp":%s,"level":"%s","file":"%s","func":"%s","message":"%s"}\n$`,
 					datetimeRegex, timestampRegex, levelRegex, fileCallerRegex, functionCallerRegex, messageRegex,
 				),
 			},
@@ -303,7 +644,7 @@ func TestEncoderJSON_Encode(t *testing.T) { // nolint:funlen,dupl
 		{
 			args: testEncodeArgs{
 				cfg: Config{
-					Fields:    []Field{{"foo", "bar"}},
+					Fields:    []Field{{Key: "foo", Value: "bar"}},
 					UTC:       true,
 					Datetime:  true,
 					Timestamp: true,
@@ -317,7 +658,7 @@ func TestEncoderJSON_Encode(t *testing.T) { // nolint:funlen,dupl
 			},
 			want: testEncodeWant{
 				lineRegexExpr: fmt.Sprintf(
-					`^{"datetime":"%s","timestamp":"%s","level":"%s","file":"%s","func":"%s",%s,"message":"%s"}\n$`,
+					`^{"datetime":"%s","timestamp":%s,"level":"%s","file":"%s","func":"%s",%s,"message":"%s"}\n$`,
 					datetimeRegex, timestampRegex, levelRegex, fileCallerRegex,
 					functionCallerRegex, fieldsJSONRegex, messageRegex,
 				),
@@ -326,7 +667,7 @@ func TestEncoderJSON_Encode(t *testing.T) { // nolint:funlen,dupl
 		{
 			args: testEncodeArgs{
 				cfg: Config{
-					Fields:    []Field{{"foo", `id: "bar"`}},
+					Fields:    []Field{{Key: "foo", Value: `id: "bar"`}},
 					UTC:       true,
 					Datetime:  true,
 					Timestamp: true,
@@ -340,7 +681,7 @@ func TestEncoderJSON_Encode(t *testing.T) { // nolint:funlen,dupl
 			},
 			want: testEncodeWant{
 				lineRegexExpr: fmt.Sprintf(
-					`^{"datetime":"%s","timestamp":"%s","level":"%s","file":"%s","func":"%s",%s,"message":"%s"}\n$`,
+					`^{"datetime":"%s","timestamp":%s,"level":"%s","file":"%s","func":"%s",%s,"message":"%s"}\n$`,
 					datetimeRegex, timestampRegex, levelRegex, fileCallerRegex,
 					functionCallerRegex, fieldsJSONRegex, messageRegex,
 				),
@@ -349,7 +690,7 @@ func TestEncoderJSON_Encode(t *testing.T) { // nolint:funlen,dupl
 		{
 			args: testEncodeArgs{
 				cfg: Config{
-					Fields:    []Field{{"foo", "bar"}},
+					Fields:    []Field{{Key: "foo", Value: "bar"}},
 					UTC:       true,
 					Datetime:  true,
 					Timestamp: true,
@@ -363,12 +704,36 @@ func TestEncoderJSON_Encode(t *testing.T) { // nolint:funlen,dupl
 			},
 			want: testEncodeWant{
 				lineRegexExpr: fmt.Sprintf(
-					`^{"datetime":"%s","timestamp":"%s","file":"%s","func":"%s",%s,"message":"%s"}\n$`,
+					`^{"datetime":"%s","timestamp":%s,"file":"%s","func":"%s",%s,"message":"%s"}\n$`,
 					datetimeRegex, timestampRegex, fileCallerRegex,
 					functionCallerRegex, fieldsJSONRegex, messageRegex,
 				),
 			},
 		},
+		{ // entry fields case
+			args: testEncodeArgs{
+				cfg: Config{
+					Fields:    []Field{{Key: "foo", Value: "bar"}},
+					UTC:       true,
+					Datetime:  true,
+					Timestamp: true,
+					Shortfile: true,
+					Longfile:  true,
+					Function:  true,
+				},
+				level:  DEBUG,
+				msg:    "Hello %s",
+				args:   []interface{}{"world"},
+				fields: []Field{{Key: "buzz", Value: 1}},
+			},
+			want: testEncodeWant{
+				lineRegexExpr: fmt.Sprintf(
+					`^{"datetime":"%s","timestamp":%s,"level":"%s","file":"%s","func":"%s",%s,%s,"message":"%s"}\n$`,
+					datetimeRegex, timestampRegex, levelRegex, fileCallerRegex,
+					functionCallerRegex, fieldsJSONRegex, fieldsJSONRegex, messageRegex,
+				),
+			},
+		},
 	}
 
 	enc := newTestEncoderJSON()
@@ -380,3 +745,8 @@ func BenchmarkEncoderJSON_Encode(b *testing.B) {
 	enc := newTestEncoderJSON()
 	benchmarkEncoderEncode(b, enc)
 }
+
+func BenchmarkEncoderJSON_Encode_fields(b *testing.B) {
+	enc := newTestEncoderJSON()
+	benchmarkEncoderEncodeFields(b, enc)
+}
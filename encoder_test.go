@@ -20,10 +20,11 @@ const (
 )
 
 type testEncodeArgs struct {
-	cfg   Config
-	level Level
-	msg   string
-	args  []interface{}
+	cfg    Config
+	level  Level
+	msg    string
+	args   []interface{}
+	fields []Field
 }
 
 type testEncodeWant struct {
@@ -63,6 +64,55 @@ func (enc *mockEncoder) Encode(buf *Buffer, e Entry) error {
 	return enc.encode(buf, e)
 }
 
+type testObject struct {
+	id      int64
+	name    string
+	roles   testArray
+	address *testObject
+	err     error
+}
+
+type testArray []interface{}
+
+func (o testObject) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddInt64("id", o.id)
+	enc.AddString("name", o.name)
+
+	if o.roles != nil {
+		enc.AddArray("roles", o.roles)
+	}
+
+	if o.address != nil {
+		enc.AddObject("address", o.address)
+	}
+
+	return o.err
+}
+
+func (a testArray) MarshalLogArray(enc ArrayEncoder) error {
+	for _, v := range a {
+		switch value := v.(type) {
+		case string:
+			enc.AppendString(value)
+		case ObjectMarshaler:
+			enc.AppendObject(value)
+		default:
+			enc.AppendAny(value)
+		}
+	}
+
+	return nil
+}
+
+func newTestObject() testObject {
+	return testObject{
+		id:      1,
+		name:    "savsgio",
+		roles:   testArray{"admin", testObject{id: 2, name: "dev"}},
+		address: &testObject{id: 3, name: "street \"1\""},
+	}
+}
+
 func testEncoderEncode(t *testing.T, enc Encoder, testCases []testEncodeCase) {
 	t.Helper()
 
@@ -77,7 +127,7 @@ func testEncoderEncode(t *testing.T, enc Encoder, testCases []testEncodeCase) {
 
 		var caller runtime.Frame
 		if cfg.Shortfile || cfg.Longfile {
-			caller = getFileCaller(3)
+			caller = getFileCaller(nil, 3).frame
 		}
 
 		t.Run("", func(t *testing.T) {
@@ -94,6 +144,7 @@ func testEncoderEncode(t *testing.T, enc Encoder, testCases []testEncodeCase) {
 				Level:   test.args.level,
 				Caller:  caller,
 				Message: buf.formatMessage(test.args.msg, test.args.args),
+				Fields:  test.args.fields,
 			}
 
 			if err := enc.Encode(buf, e); err != nil {
@@ -121,7 +172,7 @@ func benchmarkEncoderEncode(b *testing.B, enc Encoder) {
 		Config:  newTestConfig(),
 		Time:    time.Now().UTC(),
 		Level:   DEBUG,
-		Caller:  getFileCaller(4),
+		Caller:  getFileCaller(nil, 4).frame,
 		Message: `failed to request: jojoj""""`,
 	}
 
@@ -138,6 +189,54 @@ func benchmarkEncoderEncode(b *testing.B, enc Encoder) {
 	}
 }
 
+func benchmarkEncoderEncodeFields(b *testing.B, enc Encoder) {
+	b.Helper()
+
+	buf := AcquireBuffer()
+	defer ReleaseBuffer(buf)
+
+	e := Entry{
+		Config:  Config{},
+		Level:   DEBUG,
+		Message: "hello world",
+	}
+
+	enc.Configure(e.Config)
+
+	benchs := []struct {
+		name  string
+		field Field
+	}{
+		{name: "String", field: String("key", "value")},
+		{name: "Int64", field: Int64("key", 1234567)},
+		{name: "Float64", field: Float64("key", 1234.567)},
+		{name: "Bool", field: Bool("key", true)},
+		{name: "Duration", field: Duration("key", 1500*time.Millisecond)},
+		{name: "Time", field: Time("key", time.Now())},
+		{name: "Bytes", field: Bytes("key", []byte("value"))},
+		{name: "Any", field: Any("key", 1234567)},
+	}
+
+	for i := range benchs {
+		bench := benchs[i]
+
+		b.Run(bench.name, func(b *testing.B) {
+			e.Fields = []Field{bench.field}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if err := enc.Encode(buf, e); err != nil {
+					b.Fatal(err)
+				}
+
+				buf.Reset()
+			}
+		})
+	}
+}
+
 func newTestEncoderBase() *EncoderBase {
 	enc := new(EncoderBase)
 
@@ -1,5 +1,7 @@
 package logger
 
+import "time"
+
 // NewEncoderText creates a new text encoder.
 func NewEncoderText(cfg EncoderTextConfig) *EncoderText {
 	if cfg.Separator == "" {
@@ -14,6 +16,14 @@ func NewEncoderText(cfg EncoderTextConfig) *EncoderText {
 		cfg.TimestampFormat = defaultTimestampFormat
 	}
 
+	if cfg.EscapeMode == 0 {
+		cfg.EscapeMode = defaultTextEscapeMode
+	}
+
+	if cfg.LevelFormat == 0 {
+		cfg.LevelFormat = defaultLevelFormat
+	}
+
 	enc := new(EncoderText)
 	enc.cfg = cfg
 
@@ -24,29 +34,136 @@ func NewEncoderText(cfg EncoderTextConfig) *EncoderText {
 func (enc *EncoderText) Copy() Encoder {
 	copyEnc := NewEncoderText(enc.cfg)
 	copyEnc.EncoderBase = *enc.EncoderBase.Copy()
+	copyEnc.namespace = enc.namespace
 
 	return copyEnc
 }
 
+func (enc *EncoderText) escape(buf *Buffer, startAt int) {
+	switch enc.cfg.EscapeMode {
+	case TextEscapeAll:
+		buf.EscapeControl(startAt, false)
+	case TextEscapeMultiline:
+		buf.EscapeControl(startAt, true)
+	case TextEscapeNone:
+	}
+}
+
+func (enc *EncoderText) writeKey(buf *Buffer, prefix, key string) {
+	n := buf.Len()
+	buf.WriteString(prefix) // nolint:errcheck
+	buf.WriteString(key)    // nolint:errcheck
+	enc.escape(buf, n)
+
+	buf.WriteByte('=') // nolint:errcheck
+}
+
+// writeFields writes the given fields with the given namespace prefix,
+// returning the namespace prefix for the subsequent fields.
+func (enc *EncoderText) writeFields(buf *Buffer, namespace string, fields []Field) string {
+	for _, field := range fields {
+		if field.typ == fieldTypeNamespace {
+			namespace += field.Key + "."
+
+			continue
+		}
+
+		enc.writeField(buf, namespace, field)
+	}
+
+	return namespace
+}
+
+// writeField writes the given field, flattening the objects with dotted keys.
+func (enc *EncoderText) writeField(buf *Buffer, prefix string, field Field) {
+	if field.marshalerType() != fieldTypeObject || isNilValue(field.Value) {
+		enc.writeKey(buf, prefix, field.Key)
+		enc.writeFieldValue(buf, field)
+		buf.WriteString(enc.cfg.Separator) // nolint:errcheck
+
+		return
+	}
+
+	n := buf.Len()
+	oe := &textObjectEncoder{enc: enc, buf: buf, prefix: prefix + field.Key + "."}
+
+	if err := field.Value.(ObjectMarshaler).MarshalLogObject(oe); err != nil { // nolint:forcetypeassert
+		buf.truncate(n)
+		enc.writeField(buf, prefix, stringField(field.Key, err.Error()))
+	}
+}
+
+func (enc *EncoderText) writeFieldValue(buf *Buffer, field Field) {
+	switch field.marshalerType() {
+	case fieldTypeObject:
+		enc.writeInlineObject(buf, field.Value.(ObjectMarshaler)) // nolint:forcetypeassert
+	case fieldTypeArray:
+		enc.writeArray(buf, field.Value.(ArrayMarshaler)) // nolint:forcetypeassert
+	default:
+		n := buf.Len()
+		buf.WriteFieldValue(field, enc.cfg.DatetimeLayout)
+		enc.escape(buf, n)
+	}
+}
+
+func (enc *EncoderText) writeMarshalerError(buf *Buffer, startAt int, err error) {
+	buf.truncate(startAt)
+	enc.writeFieldValue(buf, stringField("", err.Error()))
+}
+
+func (enc *EncoderText) writeInlineObject(buf *Buffer, value ObjectMarshaler) {
+	if isNilValue(value) {
+		buf.WriteString("<nil>") // nolint:errcheck
+
+		return
+	}
+
+	n := buf.Len()
+	buf.WriteByte('{') // nolint:errcheck
+
+	if err := value.MarshalLogObject(&textObjectEncoder{enc: enc, buf: buf, inline: true}); err != nil {
+		enc.writeMarshalerError(buf, n, err)
+
+		return
+	}
+
+	buf.WriteByte('}') // nolint:errcheck
+}
+
+func (enc *EncoderText) writeArray(buf *Buffer, value ArrayMarshaler) {
+	if isNilValue(value) {
+		buf.WriteString("<nil>") // nolint:errcheck
+
+		return
+	}
+
+	n := buf.Len()
+	buf.WriteByte('[') // nolint:errcheck
+
+	if err := value.MarshalLogArray(&textArrayEncoder{enc: enc, buf: buf}); err != nil {
+		enc.writeMarshalerError(buf, n, err)
+
+		return
+	}
+
+	buf.WriteByte(']') // nolint:errcheck
+}
+
 // Configure configures then encoder.
 //
 // - Encondes and sets the fields.
+//
+// NOTE: The fields with lazy values are encoded with each entry.
 func (enc *EncoderText) Configure(cfg Config) {
-	if len(cfg.Fields) == 0 {
+	if len(cfg.Fields) == 0 || cfg.lazy {
 		enc.SetFieldsEncoded("")
+		enc.namespace = ""
 
 		return
 	}
 
 	buf := AcquireBuffer()
-
-	for _, field := range cfg.Fields {
-		buf.WriteString(field.Key) // nolint:errcheck
-		buf.WriteString("=")       // nolint:errcheck
-		buf.WriteInterface(field.Value)
-		buf.WriteString(enc.cfg.Separator) // nolint:errcheck
-	}
-
+	enc.namespace = enc.writeFields(buf, "", cfg.Fields)
 	enc.SetFieldsEncoded(buf.String())
 
 	ReleaseBuffer(buf)
@@ -64,13 +181,13 @@ func (enc *EncoderText) Encode(buf *Buffer, e Entry) error {
 		buf.WriteString(enc.cfg.Separator) // nolint:errcheck
 	}
 
-	if levelStr := e.Level.String(); levelStr != "" {
+	if levelStr, _ := e.Level.format(enc.cfg.LevelFormat, enc.cfg.LevelNames, enc.cfg.PrintLevel); levelStr != "" {
 		buf.WriteString(levelStr)          // nolint:errcheck
 		buf.WriteString(enc.cfg.Separator) // nolint:errcheck
 	}
 
 	if e.Config.Shortfile || e.Config.Longfile {
-		buf.WriteFileCaller(e.Caller, e.Config.Shortfile)
+		buf.writeEntryFileCaller(&e)
 		buf.WriteString(enc.cfg.Separator) // nolint:errcheck
 	}
 
@@ -79,9 +196,136 @@ func (enc *EncoderText) Encode(buf *Buffer, e Entry) error {
 		buf.WriteString(enc.cfg.Separator) // nolint:errcheck
 	}
 
-	buf.WriteString(enc.FieldsEncoded()) // nolint:errcheck
-	buf.WriteString(e.Message)           // nolint:errcheck
+	namespace := enc.namespace
+
+	if e.Config.lazy {
+		namespace = enc.writeFields(buf, "", e.Config.Fields)
+	} else {
+		buf.WriteString(enc.FieldsEncoded()) // nolint:errcheck
+	}
+
+	enc.writeFields(buf, namespace, e.Fields)
+
+	n := buf.Len()
+	buf.WriteString(e.Message) // nolint:errcheck
+	enc.escape(buf, n)
+
 	buf.WriteNewLine()
 
 	return nil
 }
+
+func (oe *textObjectEncoder) addField(field Field) {
+	if !oe.inline {
+		oe.enc.writeField(oe.buf, oe.prefix, field)
+
+		return
+	}
+
+	if oe.size > 0 {
+		oe.buf.WriteByte(' ') // nolint:errcheck
+	}
+
+	oe.enc.writeKey(oe.buf, "", field.Key)
+	oe.enc.writeFieldValue(oe.buf, field)
+	oe.size++
+}
+
+// AddString adds a string value.
+func (oe *textObjectEncoder) AddString(key, value string) {
+	oe.addField(stringField(key, value))
+}
+
+// AddInt64 adds an int64 value.
+func (oe *textObjectEncoder) AddInt64(key string, value int64) {
+	oe.addField(int64Field(key, value))
+}
+
+// AddFloat64 adds a float64 value.
+func (oe *textObjectEncoder) AddFloat64(key string, value float64) {
+	oe.addField(float64Field(key, value))
+}
+
+// AddBool adds a bool value.
+func (oe *textObjectEncoder) AddBool(key string, value bool) {
+	oe.addField(boolField(key, value))
+}
+
+// AddDuration adds a duration value.
+func (oe *textObjectEncoder) AddDuration(key string, value time.Duration) {
+	oe.addField(durationField(key, value))
+}
+
+// AddTime adds a time value.
+func (oe *textObjectEncoder) AddTime(key string, value time.Time) {
+	oe.addField(timeField(key, value))
+}
+
+// AddObject adds an object value.
+func (oe *textObjectEncoder) AddObject(key string, value ObjectMarshaler) {
+	oe.addField(Object(key, value))
+}
+
+// AddArray adds an array value.
+func (oe *textObjectEncoder) AddArray(key string, value ArrayMarshaler) {
+	oe.addField(Array(key, value))
+}
+
+// AddAny adds a value of any type.
+func (oe *textObjectEncoder) AddAny(key string, value interface{}) {
+	oe.addField(Any(key, value))
+}
+
+func (ae *textArrayEncoder) appendField(field Field) {
+	if ae.size > 0 {
+		ae.buf.WriteByte(' ') // nolint:errcheck
+	}
+
+	ae.enc.writeFieldValue(ae.buf, field)
+	ae.size++
+}
+
+// AppendString appends a string value.
+func (ae *textArrayEncoder) AppendString(value string) {
+	ae.appendField(stringField("", value))
+}
+
+// AppendInt64 appends an int64 value.
+func (ae *textArrayEncoder) AppendInt64(value int64) {
+	ae.appendField(int64Field("", value))
+}
+
+// AppendFloat64 appends a float64 value.
+func (ae *textArrayEncoder) AppendFloat64(value float64) {
+	ae.appendField(float64Field("", value))
+}
+
+// AppendBool appends a bool value.
+func (ae *textArrayEncoder) AppendBool(value bool) {
+	ae.appendField(boolField("", value))
+}
+
+// AppendDuration appends a duration value.
+func (ae *textArrayEncoder) AppendDuration(value time.Duration) {
+	ae.appendField(durationField("", value))
+}
+
+// AppendTime appends a time value.
+func (ae *textArrayEncoder) AppendTime(value time.Time) {
+	ae.appendField(timeField("", value))
+}
+
+// AppendObject appends an object value.
+func (ae *textArrayEncoder) AppendObject(value ObjectMarshaler) {
+	ae.appendField(Object("", value))
+}
+
+// AppendArray appends an array value.
+func (ae *textArrayEncoder) AppendArray(value ArrayMarshaler) {
+	ae.appendField(Array("", value))
+}
+
+// AppendAny appends a value of any type.
+func (ae *textArrayEncoder) AppendAny(value interface{}) {
+	ae.appendField(Any("", value))
+}
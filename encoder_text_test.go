@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -38,6 +39,8 @@ func Test_NewEncoderText(t *testing.T) {
 					Separator:       defaultTextSeparator,
 					DatetimeLayout:  defaultDatetimeLayout,
 					TimestampFormat: defaultTimestampFormat,
+					EscapeMode:      defaultTextEscapeMode,
+					LevelFormat:     defaultLevelFormat,
 				},
 			},
 		},
@@ -47,6 +50,8 @@ func Test_NewEncoderText(t *testing.T) {
 					Separator:       "#",
 					DatetimeLayout:  time.RFC1123,
 					TimestampFormat: TimestampFormatNanoseconds,
+					EscapeMode:      TextEscapeMultiline,
+					LevelFormat:     LevelFormatLower,
 				},
 			},
 			want: want{
@@ -54,6 +59,8 @@ func Test_NewEncoderText(t *testing.T) {
 					Separator:       "#",
 					DatetimeLayout:  time.RFC1123,
 					TimestampFormat: TimestampFormatNanoseconds,
+					EscapeMode:      TextEscapeMultiline,
+					LevelFormat:     LevelFormatLower,
 				},
 			},
 		},
@@ -119,13 +126,54 @@ func TestEncoderText_Configure(t *testing.T) {
 		{
 			args: args{
 				cfg: Config{
-					Fields: []Field{{"foo", "bar"}, {"buzz", []int{1, 2, 3}}},
+					Fields: []Field{{Key: "foo", Value: "bar"}, {Key: "buzz", Value: []int{1, 2, 3}}},
 				},
 			},
 			want: want{
 				fieldsEncoded: "foo=bar" + enc.cfg.Separator + "buzz=[1 2 3]" + enc.cfg.Separator,
 			},
 		},
+		{
+			args: args{
+				cfg: Config{
+					Fields: []Field{String("s", "a"), Int64("i", -1), Bool("b", true), Duration("d", time.Second)},
+				},
+			},
+			want: want{
+				fieldsEncoded: "s=a" + enc.cfg.Separator + "i=-1" + enc.cfg.Separator + "b=true" +
+					enc.cfg.Separator + "d=1s" + enc.cfg.Separator,
+			},
+		},
+		{
+			args: args{
+				cfg: Config{
+					Fields: []Field{
+						Object("user", newTestObject()), Any("roles", testArray{"a", 1}),
+						Object("invalid", testObject{id: 4, err: errors.New("failed")}),
+					},
+				},
+			},
+			want: want{
+				fieldsEncoded: "user.id=1" + enc.cfg.Separator + "user.name=savsgio" + enc.cfg.Separator +
+					"user.roles=[admin {id=2 name=dev}]" + enc.cfg.Separator + "user.address.id=3" + enc.cfg.Separator +
+					`user.address.name=street "1"` + enc.cfg.Separator + "roles=[a 1]" + enc.cfg.Separator +
+					"invalid=failed" + enc.cfg.Separator,
+			},
+		},
+		{
+			args: args{
+				cfg: Config{
+					Fields: []Field{
+						Any("user", (*testObject)(nil)), Object("address", (*testObject)(nil)),
+						Array("roles", testArray(nil)), Array("users", testArray{(*testObject)(nil)}),
+					},
+				},
+			},
+			want: want{
+				fieldsEncoded: "user=<nil>" + enc.cfg.Separator + "address=<nil>" + enc.cfg.Separator +
+					"roles=<nil>" + enc.cfg.Separator + "users=[<nil>]" + enc.cfg.Separator,
+			},
+		},
 	}
 
 	for i := range tests {
@@ -143,6 +191,119 @@ func TestEncoderText_Configure(t *testing.T) {
 	}
 }
 
+func TestEncoderText_Encode_namespaces(t *testing.T) {
+	buf := AcquireBuffer()
+	defer ReleaseBuffer(buf)
+
+	cfg := Config{
+		Fields: []Field{String("id", "1"), Namespace("http"), String("method", "GET")},
+	}
+
+	enc := NewEncoderText(EncoderTextConfig{})
+	enc.Configure(cfg)
+
+	e := Entry{
+		Config:  cfg,
+		Level:   INFO,
+		Message: "hello",
+		Fields:  []Field{Int("status", 200), Namespace("req"), Int("size", 10)},
+	}
+
+	if err := enc.Copy().Encode(buf, e); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "INFO - id=1 - http.method=GET - http.status=200 - http.req.size=10 - hello\n"
+	if result := buf.String(); result != want {
+		t.Errorf("result == %s, want %s", result, want)
+	}
+}
+
+func TestEncoderText_Encode_level(t *testing.T) { // nolint:funlen
+	type args struct {
+		cfg   EncoderTextConfig
+		level Level
+	}
+
+	type want struct {
+		result string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Upper",
+			args: args{
+				level: WARNING,
+			},
+			want: want{
+				result: "WARNING - hello\n",
+			},
+		},
+		{
+			name: "Short",
+			args: args{
+				cfg:   EncoderTextConfig{LevelFormat: LevelFormatShort},
+				level: WARNING,
+			},
+			want: want{
+				result: "WARN - hello\n",
+			},
+		},
+		{
+			name: "LevelNames",
+			args: args{
+				cfg: EncoderTextConfig{
+					LevelFormat: LevelFormatLower,
+					LevelNames:  map[Level]string{FATAL: "critical"},
+				},
+				level: FATAL,
+			},
+			want: want{
+				result: "critical - hello\n",
+			},
+		},
+		{
+			name: "PrintLevel",
+			args: args{
+				cfg:   EncoderTextConfig{PrintLevel: true},
+				level: PRINT,
+			},
+			want: want{
+				result: "PRINT - hello\n",
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			buf := AcquireBuffer()
+			defer ReleaseBuffer(buf)
+
+			enc := NewEncoderText(test.args.cfg)
+			enc.Configure(Config{})
+
+			e := Entry{
+				Level:   test.args.level,
+				Message: "hello",
+			}
+
+			if err := enc.Encode(buf, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result := buf.String(); result != test.want.result {
+				t.Errorf("result == %s, want %s", result, test.want.result)
+			}
+		})
+	}
+}
+
 func TestEncoderText_Encode(t *testing.T) { // nolint:funlen,dupl
 	testCases := []testEncodeCase{
 		{
@@ -183,7 +344,7 @@ func TestEncoderText_Encode(t *testing.T) { // nolint:funlen,dupl
 		{
 			args: testEncodeArgs{
 				cfg: Config{
-					Fields:    []Field{{"foo", "bar"}},
+					Fields:    []Field{{Key: "foo", Value: "bar"}},
 					UTC:       true,
 					Datetime:  true,
 					Timestamp: true,
@@ -206,7 +367,7 @@ func TestEncoderText_Encode(t *testing.T) { // nolint:funlen,dupl
 		{ // print/printf case
 			args: testEncodeArgs{
 				cfg: Config{
-					Fields:    []Field{{"foo", "bar"}},
+					Fields:    []Field{{Key: "foo", Value: "bar"}},
 					UTC:       true,
 					Datetime:  true,
 					Timestamp: true,
@@ -226,6 +387,30 @@ func TestEncoderText_Encode(t *testing.T) { // nolint:funlen,dupl
 				),
 			},
 		},
+		{ // entry fields case
+			args: testEncodeArgs{
+				cfg: Config{
+					Fields:    []Field{{Key: "foo", Value: "bar"}},
+					UTC:       true,
+					Datetime:  true,
+					Timestamp: true,
+					Shortfile: true,
+					Longfile:  true,
+					Function:  true,
+				},
+				level:  DEBUG,
+				msg:    "Hello %s",
+				args:   []interface{}{"world"},
+				fields: []Field{{Key: "buzz", Value: 1}},
+			},
+			want: testEncodeWant{
+				lineRegexExpr: fmt.Sprintf(
+					"^%s - %s - %s - %s - %s - %s - %s - %s\n$",
+					datetimeRegex, timestampRegex, levelRegex, fileCallerRegex,
+					functionCallerRegex, fieldsTextRegex, fieldsTextRegex, messageRegex,
+				),
+			},
+		},
 	}
 
 	enc := newTestEncoderText()
@@ -233,7 +418,84 @@ func TestEncoderText_Encode(t *testing.T) { // nolint:funlen,dupl
 	testEncoderEncode(t, enc, testCases)
 }
 
+func TestEncoderText_Encode_escape(t *testing.T) { // nolint:funlen
+	type args struct {
+		escapeMode TextEscapeMode
+	}
+
+	type want struct {
+		result string
+	}
+
+	msg := "done\nINFO - forged line \x1b[31mred\x1b[0m\n\tat main.go:10"
+	field := Field{Key: "user\r", Value: "savsgio\ninjected"}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "all",
+			args: args{
+				escapeMode: TextEscapeAll,
+			},
+			want: want{
+				result: `INFO - user\r=savsgio\ninjected - done\nINFO - forged line \x1b[31mred\x1b[0m\n\tat main.go:10` + "\n",
+			},
+		},
+		{
+			name: "multiline",
+			args: args{
+				escapeMode: TextEscapeMultiline,
+			},
+			want: want{
+				result: "INFO - user\\r=savsgio\ninjected - done\nINFO - forged line \\x1b[31mred\\x1b[0m\n\tat main.go:10\n",
+			},
+		},
+		{
+			name: "none",
+			args: args{
+				escapeMode: TextEscapeNone,
+			},
+			want: want{
+				result: "INFO - user\r=savsgio\ninjected - " + msg + "\n",
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			buf := AcquireBuffer()
+			defer ReleaseBuffer(buf)
+
+			enc := NewEncoderText(EncoderTextConfig{EscapeMode: test.args.escapeMode})
+
+			e := Entry{
+				Level:   INFO,
+				Message: msg,
+				Fields:  []Field{field},
+			}
+
+			if err := enc.Encode(buf, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result := buf.String(); result != test.want.result {
+				t.Errorf("result == %q, want %q", result, test.want.result)
+			}
+		})
+	}
+}
+
 func BenchmarkEncoderText_Encode(b *testing.B) {
 	enc := newTestEncoderText()
 	benchmarkEncoderEncode(b, enc)
 }
+
+func BenchmarkEncoderText_Encode_fields(b *testing.B) {
+	enc := newTestEncoderText()
+	benchmarkEncoderEncodeFields(b, enc)
+}
@@ -1,11 +1,67 @@
 package logger
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
 	// ErrInvalidLevel is the invalid level error.
 	ErrInvalidLevel = errors.New("invalid level")
 
+	// ErrEmptyLevelName is the empty level name error.
+	ErrEmptyLevelName = errors.New("empty level name")
+
+	// ErrLevelAlreadyRegistered is the level already registered error.
+	ErrLevelAlreadyRegistered = errors.New("level already registered")
+
 	// ErrEmptyHookLevels is the empty hook levels error.
 	ErrEmptyHookLevels = errors.New("empty hook levels")
 )
+
+// err returns the multi error, or nil if empty.
+func (m MultiError) err() error {
+	if len(m) == 0 {
+		return nil
+	}
+
+	return m
+}
+
+// Error returns the messages of all the errors.
+func (m MultiError) Error() string {
+	msgs := make([]string, len(m))
+
+	for i, err := range m {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns all the errors.
+func (m MultiError) Unwrap() []error {
+	return m
+}
+
+// Is reports whether any of the errors matches the target.
+func (m MultiError) Is(target error) bool {
+	for _, err := range m {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error which matches the target, and if so, sets the target to it.
+func (m MultiError) As(target interface{}) bool {
+	for _, err := range m {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := &os.PathError{Op: "sync", Path: "file", Err: io.ErrShortWrite}
+
+	err := MultiError{errFirst, errSecond}.err()
+
+	if want := "first; sync file: short write"; err.Error() != want {
+		t.Errorf("Error() == %s, want %s", err.Error(), want)
+	}
+
+	if !errors.Is(err, errFirst) || !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("errors.Is(%v) == false, want true", err)
+	}
+
+	if errors.Is(err, io.EOF) {
+		t.Errorf("errors.Is(%v, %v) == true, want false", err, io.EOF)
+	}
+
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) || pathErr != errSecond {
+		t.Errorf("errors.As(%v) == %v, want %v", err, pathErr, errSecond)
+	}
+
+	if len(err.(MultiError).Unwrap()) != 2 { // nolint:errorlint,forcetypeassert
+		t.Errorf("Unwrap() == %v, want 2 errors", err)
+	}
+
+	if err := (MultiError{}).err(); err != nil {
+		t.Errorf("err() == %v, want nil", err)
+	}
+}
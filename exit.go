@@ -0,0 +1,73 @@
+package logger
+
+import "time"
+
+var exitHandlers = new(exitHandlerRegistry)
+
+// RegisterExitHandler registers a handler which is run before exiting by a FATAL entry,
+// like to release resources or to flush other sinks.
+//
+// The handlers are run in the same order as registered, and their panics are recovered.
+func RegisterExitHandler(handler func()) {
+	exitHandlers.mu.Lock()
+	exitHandlers.handlers = append(exitHandlers.handlers, handler)
+	exitHandlers.mu.Unlock()
+}
+
+func (r *exitHandlerRegistry) run() {
+	r.mu.Lock()
+	handlers := append([]func(){}, r.handlers...)
+	r.mu.Unlock()
+
+	for _, handler := range handlers {
+		runExitHandler(handler)
+	}
+}
+
+func runExitHandler(handler func()) {
+	defer func() {
+		recover() // nolint:errcheck
+	}()
+
+	handler()
+}
+
+// exitWithCode runs the exit handlers and flushes the output and hooks,
+// waiting up to the exit timeout, or without limit if it's not positive,
+// and then exits with the given code.
+func (l *Logger) exitWithCode(code int) {
+	l.mu.RLock()
+	timeout := l.exitTimeout
+	l.mu.RUnlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		exitHandlers.run()
+		l.flush()
+		close(done)
+	}()
+
+	if timeout <= 0 {
+		<-done
+		l.exit(code)
+
+		return
+	}
+
+	timer := time.NewTimer(timeout)
+
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+
+	timer.Stop()
+
+	l.exit(code)
+}
+
+// flush commits the buffered data of the output and hooks, ignoring the errors.
+func (l *Logger) flush() {
+	l.Sync() // nolint:errcheck
+}
@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func withExitHandlers(handlers ...func()) func() {
+	exitHandlers.mu.Lock()
+	prev := exitHandlers.handlers
+	exitHandlers.handlers = handlers
+	exitHandlers.mu.Unlock()
+
+	return func() {
+		exitHandlers.mu.Lock()
+		exitHandlers.handlers = prev
+		exitHandlers.mu.Unlock()
+	}
+}
+
+func TestRegisterExitHandler(t *testing.T) {
+	defer withExitHandlers()()
+
+	var calls []int
+
+	RegisterExitHandler(func() { calls = append(calls, 1) })
+	RegisterExitHandler(func() { panic("handler panic") })
+	RegisterExitHandler(func() { calls = append(calls, 3) })
+
+	exitHandlers.run()
+
+	if want := []int{1, 3}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls == %v, want %v", calls, want)
+	}
+}
+
+func TestLogger_exitWithCode(t *testing.T) {
+	handled := false
+
+	defer withExitHandlers(func() { handled = true })()
+
+	output := &mockFlushSyncer{Writer: new(bytes.Buffer)}
+	hook := new(mockFlushHook)
+
+	l := New(INFO, output)
+	l.AddHook(hook) // nolint:errcheck
+
+	exitCode := -1
+	l.exit = func(code int) { exitCode = code }
+
+	l.exitWithCode(3)
+
+	if exitCode != 3 {
+		t.Errorf("exit code == %d, want %d", exitCode, 3)
+	}
+
+	if !handled {
+		t.Error("the exit handler has not been run")
+	}
+
+	if output.flushed != 1 || output.synced != 1 {
+		t.Errorf("output flushed/synced == %d/%d, want 1/1", output.flushed, output.synced)
+	}
+
+	if hook.flushed != 1 || hook.synced != 1 {
+		t.Errorf("hook flushed/synced == %d/%d, want 1/1", hook.flushed, hook.synced)
+	}
+}
+
+func TestLogger_exitWithCode_timeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	defer withExitHandlers(func() { <-block })()
+
+	l := New(INFO, io.Discard)
+	l.SetExitTimeout(10 * time.Millisecond)
+
+	exitCode := -1
+	l.exit = func(code int) { exitCode = code }
+
+	start := time.Now()
+
+	l.exitWithCode(1)
+
+	if exitCode != 1 {
+		t.Errorf("exit code == %d, want %d", exitCode, 1)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("exit took %s, want less than %s", elapsed, time.Second)
+	}
+}
+
+func TestLogger_exitWithCode_noTimeout(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		handled := false
+
+		release := withExitHandlers(func() {
+			time.Sleep(10 * time.Millisecond)
+
+			handled = true
+		})
+
+		l := New(INFO, io.Discard)
+		l.SetExitTimeout(timeout)
+
+		exitCode := -1
+		l.exit = func(code int) { exitCode = code }
+
+		l.exitWithCode(1)
+		release()
+
+		if !handled {
+			t.Errorf("the exit handler has not been run with the timeout %s", timeout)
+		}
+
+		if exitCode != 1 {
+			t.Errorf("exit code == %d, want %d", exitCode, 1)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package logger
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// String returns a field with the given string value.
+func String(key, value string) Field {
+	f := stringField(key, value)
+	f.Value = value
+
+	return f
+}
+
+// Int returns a field with the given int value.
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 returns a field with the given int64 value.
+func Int64(key string, value int64) Field {
+	f := int64Field(key, value)
+	f.Value = value
+
+	return f
+}
+
+// Float64 returns a field with the given float64 value.
+func Float64(key string, value float64) Field {
+	f := float64Field(key, value)
+	f.Value = value
+
+	return f
+}
+
+// Bool returns a field with the given bool value.
+func Bool(key string, value bool) Field {
+	f := boolField(key, value)
+	f.Value = value
+
+	return f
+}
+
+// Duration returns a field with the given duration value.
+func Duration(key string, value time.Duration) Field {
+	f := durationField(key, value)
+	f.Value = value
+
+	return f
+}
+
+// Time returns a field with the given time value.
+//
+// The time is encoded with the datetime layout of the encoder.
+func Time(key string, value time.Time) Field {
+	f := timeField(key, value)
+	f.Value = value
+
+	return f
+}
+
+// Bytes returns a field with a copy of the given bytes value, encoded as a string.
+func Bytes(key string, value []byte) Field {
+	str := string(value)
+
+	return Field{Key: key, Value: str, typ: fieldTypeBytes, str: str}
+}
+
+// Stringer returns a field with the given stringer value.
+//
+// The String method is only called when the field is encoded.
+func Stringer(key string, value fmt.Stringer) Field {
+	return Field{Key: key, Value: value, typ: fieldTypeStringer}
+}
+
+// Object returns a field with the given object marshaler value.
+func Object(key string, value ObjectMarshaler) Field {
+	return Field{Key: key, Value: value, typ: fieldTypeObject}
+}
+
+// Array returns a field with the given array marshaler value.
+func Array(key string, value ArrayMarshaler) Field {
+	return Field{Key: key, Value: value, typ: fieldTypeArray}
+}
+
+// Namespace returns a field which nests the subsequent fields under the given key.
+func Namespace(key string) Field {
+	return Field{Key: key, typ: fieldTypeNamespace}
+}
+
+// Lazy returns a field whose value is only evaluated when the entry is going to be logged, before the processors.
+func Lazy(key string, fn func() interface{}) Field {
+	return Any(key, LogValuerFunc(fn))
+}
+
+// Any returns a field with the given value of any type.
+//
+// The ObjectMarshaler and ArrayMarshaler values are also recognized.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// LogValue calls fn().
+func (fn LogValuerFunc) LogValue() interface{} {
+	return fn()
+}
+
+func (f Field) isLazy() bool {
+	if f.typ != fieldTypeAny {
+		return false
+	}
+
+	_, ok := f.Value.(LogValuer)
+
+	return ok
+}
+
+// resolve returns the field with the lazy value evaluated.
+func (f Field) resolve() Field {
+	if valuer, ok := f.Value.(LogValuer); ok && f.typ == fieldTypeAny {
+		return Any(f.Key, valuer.LogValue())
+	}
+
+	return f
+}
+
+func hasLazyFields(fields []Field) bool {
+	for i := range fields {
+		if fields[i].isLazy() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveFields returns the fields with the lazy values evaluated,
+// copying them only if needed, since the given slice could be shared.
+func resolveFields(fields []Field) []Field {
+	if !hasLazyFields(fields) {
+		return fields
+	}
+
+	result := make([]Field, len(fields))
+
+	for i := range fields {
+		result[i] = fields[i].resolve()
+	}
+
+	return result
+}
+
+// sameFields returns true if both slices are the same one.
+func sameFields(a, b []Field) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// resolveArgs returns the args with the lazy values evaluated,
+// copying them only if needed, since the given slice could be owned by the caller.
+func resolveArgs(args []interface{}) []interface{} {
+	var result []interface{}
+
+	for i := range args {
+		valuer, ok := args[i].(LogValuer)
+
+		if result == nil {
+			if !ok {
+				continue
+			}
+
+			result = make([]interface{}, len(args))
+			copy(result, args)
+		}
+
+		if ok {
+			result[i] = valuer.LogValue()
+		}
+	}
+
+	if result == nil {
+		return args
+	}
+
+	return result
+}
+
+// marshalerType returns the type of the field,
+// resolving the marshaler values of any type.
+func (f Field) marshalerType() fieldType {
+	if f.typ != fieldTypeAny {
+		return f.typ
+	}
+
+	switch f.Value.(type) {
+	case ObjectMarshaler:
+		return fieldTypeObject
+	case ArrayMarshaler:
+		return fieldTypeArray
+	default:
+		return fieldTypeAny
+	}
+}
+
+// stringField returns a string field without Value, so it does not allocate.
+//
+// NOTE: The fields without Value, like the following ones, are only used by the encoders.
+func stringField(key, value string) Field {
+	return Field{Key: key, typ: fieldTypeString, str: value}
+}
+
+func int64Field(key string, value int64) Field {
+	return Field{Key: key, typ: fieldTypeInt64, integer: value}
+}
+
+func float64Field(key string, value float64) Field {
+	return Field{Key: key, typ: fieldTypeFloat64, integer: int64(math.Float64bits(value))}
+}
+
+func boolField(key string, value bool) Field {
+	var integer int64
+	if value {
+		integer = 1
+	}
+
+	return Field{Key: key, typ: fieldTypeBool, integer: integer}
+}
+
+func durationField(key string, value time.Duration) Field {
+	return Field{Key: key, typ: fieldTypeDuration, integer: int64(value)}
+}
+
+func timeField(key string, value time.Time) Field {
+	// NOTE: Out of the range of nanoseconds since epoch.
+	if year := value.Year(); year < 1678 || year > 2261 {
+		return Any(key, value)
+	}
+
+	return Field{Key: key, typ: fieldTypeTime, integer: value.UnixNano(), loc: value.Location()}
+}
+
+// isNilValue returns whether the value is nil, or a nil pointer, map, slice, func or chan
+// boxed in the interface, like a typed nil marshaler.
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() { // nolint:exhaustive
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func (f Field) isJSONQuoted() bool {
+	switch f.typ {
+	case fieldTypeInt64, fieldTypeBool:
+		return false
+	case fieldTypeFloat64:
+		value := math.Float64frombits(uint64(f.integer))
+
+		return math.IsNaN(value) || math.IsInf(value, 0)
+	default:
+		return true
+	}
+}
+
+// IsNamespace returns whether the field is a namespace, which nests the subsequent fields.
+func (f Field) IsNamespace() bool {
+	return f.typ == fieldTypeNamespace
+}
+
+// Interface returns the value of the field.
+//
+// NOTE: The typed values are boxed, so it allocates.
+func (f Field) Interface() interface{} {
+	switch f.typ {
+	case fieldTypeString, fieldTypeBytes:
+		return f.str
+	case fieldTypeInt64:
+		return f.integer
+	case fieldTypeFloat64:
+		return math.Float64frombits(uint64(f.integer))
+	case fieldTypeBool:
+		return f.integer == 1
+	case fieldTypeDuration:
+		return time.Duration(f.integer)
+	case fieldTypeTime:
+		return f.time()
+	case fieldTypeAny, fieldTypeStringer, fieldTypeObject, fieldTypeArray, fieldTypeNamespace:
+		fallthrough
+	default:
+		return f.Value
+	}
+}
+
+func (f Field) time() time.Time {
+	t := time.Unix(0, f.integer)
+
+	if f.loc != nil {
+		t = t.In(f.loc)
+	}
+
+	return t
+}
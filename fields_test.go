@@ -0,0 +1,289 @@
+package logger
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestField_constructors(t *testing.T) { // nolint:funlen
+	now := time.Date(2024, 7, 10, 12, 30, 45, 123, time.FixedZone("CEST", 2*60*60))
+	old := time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC)
+	stringer := &url.URL{Scheme: "https", Host: "example.com"}
+
+	type want struct {
+		typ   fieldType
+		value interface{}
+	}
+
+	tests := []struct {
+		name  string
+		field Field
+		want  want
+	}{
+		{
+			name:  "String",
+			field: String("key", "value"),
+			want:  want{typ: fieldTypeString, value: "value"},
+		},
+		{
+			name:  "Int",
+			field: Int("key", 123),
+			want:  want{typ: fieldTypeInt64, value: int64(123)},
+		},
+		{
+			name:  "Int64",
+			field: Int64("key", -123),
+			want:  want{typ: fieldTypeInt64, value: int64(-123)},
+		},
+		{
+			name:  "Float64",
+			field: Float64("key", 1.5),
+			want:  want{typ: fieldTypeFloat64, value: 1.5},
+		},
+		{
+			name:  "Bool",
+			field: Bool("key", true),
+			want:  want{typ: fieldTypeBool, value: true},
+		},
+		{
+			name:  "Duration",
+			field: Duration("key", time.Second),
+			want:  want{typ: fieldTypeDuration, value: time.Second},
+		},
+		{
+			name:  "Time",
+			field: Time("key", now),
+			want:  want{typ: fieldTypeTime, value: now},
+		},
+		{
+			name:  "Time out of range",
+			field: Time("key", old),
+			want:  want{typ: fieldTypeAny, value: old},
+		},
+		{
+			name:  "Bytes",
+			field: Bytes("key", []byte("value")),
+			want:  want{typ: fieldTypeBytes, value: "value"},
+		},
+		{
+			name:  "Stringer",
+			field: Stringer("key", stringer),
+			want:  want{typ: fieldTypeStringer, value: stringer},
+		},
+		{
+			name:  "Any",
+			field: Any("key", []int{1, 2}),
+			want:  want{typ: fieldTypeAny, value: []int{1, 2}},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			if test.field.Key != "key" {
+				t.Errorf("key == %s, want %s", test.field.Key, "key")
+			}
+
+			if test.field.typ != test.want.typ {
+				t.Errorf("type == %d, want %d", test.field.typ, test.want.typ)
+			}
+
+			// NOTE: The Value is also set by the typed constructors, for the compatibility.
+			for _, value := range []interface{}{test.field.Interface(), test.field.Value} {
+				if wantTime, ok := test.want.value.(time.Time); ok {
+					if valueTime, _ := value.(time.Time); !valueTime.Equal(wantTime) ||
+						valueTime.Location() != wantTime.Location() {
+						t.Errorf("value == %v, want %v", value, wantTime)
+					}
+
+					continue
+				}
+
+				if !reflect.DeepEqual(value, test.want.value) {
+					t.Errorf("value == %v, want %v", value, test.want.value)
+				}
+			}
+		})
+	}
+}
+
+func TestBytes_copy(t *testing.T) {
+	value := []byte("value")
+	field := Bytes("key", value)
+
+	copy(value, "xxxxx")
+
+	if got := field.Interface(); got != "value" {
+		t.Errorf("value == %v, want %s", got, "value")
+	}
+}
+
+func TestField_IsNamespace(t *testing.T) {
+	tests := []struct {
+		field Field
+		want  bool
+	}{
+		{field: Namespace("key"), want: true},
+		{field: Any("key", nil), want: false},
+		{field: String("key", ""), want: false},
+		{field: Field{Key: "key"}, want: false},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		if result := test.field.IsNamespace(); result != test.want {
+			t.Errorf("field %v IsNamespace() == %v, want %v", test.field, result, test.want)
+		}
+	}
+}
+
+func TestField_isJSONQuoted(t *testing.T) {
+	tests := []struct {
+		field Field
+		want  bool
+	}{
+		{field: String("key", "value"), want: true},
+		{field: Int64("key", 1), want: false},
+		{field: Float64("key", 1.5), want: false},
+		{field: Float64("key", math.NaN()), want: true},
+		{field: Float64("key", math.Inf(1)), want: true},
+		{field: Bool("key", false), want: false},
+		{field: Duration("key", time.Second), want: true},
+		{field: Time("key", time.Now()), want: true},
+		{field: Any("key", errors.New("error")), want: true},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		if result := test.field.isJSONQuoted(); result != test.want {
+			t.Errorf("field %v quoted == %t, want %t", test.field, result, test.want)
+		}
+	}
+}
+
+func TestField_marshalerType(t *testing.T) {
+	tests := []struct {
+		field Field
+		want  fieldType
+	}{
+		{field: Object("key", testObject{}), want: fieldTypeObject},
+		{field: Array("key", testArray{}), want: fieldTypeArray},
+		{field: Any("key", testObject{}), want: fieldTypeObject},
+		{field: Any("key", testArray{}), want: fieldTypeArray},
+		{field: Any("key", 1), want: fieldTypeAny},
+		{field: String("key", "value"), want: fieldTypeString},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		if result := test.field.marshalerType(); result != test.want {
+			t.Errorf("field %v type == %d, want %d", test.field, result, test.want)
+		}
+	}
+}
+
+func Test_isNilValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  bool
+	}{
+		{value: nil, want: true},
+		{value: (*testObject)(nil), want: true},
+		{value: testArray(nil), want: true},
+		{value: (map[string]int)(nil), want: true},
+		{value: &testObject{}, want: false},
+		{value: testObject{}, want: false},
+		{value: testArray{}, want: false},
+		{value: 0, want: false},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		if result := isNilValue(test.value); result != test.want {
+			t.Errorf("isNilValue(%#v) == %v, want %v", test.value, result, test.want)
+		}
+	}
+}
+
+func TestField_Lazy(t *testing.T) {
+	calls := 0
+
+	field := Lazy("key", func() interface{} {
+		calls++
+
+		return "value"
+	})
+
+	if !field.isLazy() {
+		t.Fatal("field is not lazy")
+	}
+
+	if calls != 0 {
+		t.Errorf("calls == %d, want %d", calls, 0)
+	}
+
+	resolved := field.resolve()
+
+	if resolved.isLazy() {
+		t.Error("resolved field is lazy")
+	}
+
+	if resolved.Key != "key" || resolved.Value != "value" {
+		t.Errorf("resolved field == %v, want %v", resolved, Any("key", "value"))
+	}
+
+	if calls != 1 {
+		t.Errorf("calls == %d, want %d", calls, 1)
+	}
+
+	if field := String("key", "value"); field.isLazy() || !reflect.DeepEqual(field.resolve(), field) {
+		t.Errorf("unexpected lazy field: %v", field)
+	}
+}
+
+func Test_resolveFields(t *testing.T) {
+	fields := []Field{String("foo", "bar")}
+
+	if result := resolveFields(fields); &result[0] != &fields[0] {
+		t.Error("the fields have been copied without lazy values")
+	}
+
+	fields = append(fields, Lazy("buzz", func() interface{} { return 1 }))
+	result := resolveFields(fields)
+
+	if wantResult := []Field{String("foo", "bar"), Any("buzz", 1)}; !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("fields == %v, want %v", result, wantResult)
+	}
+
+	if !fields[1].isLazy() {
+		t.Error("the given fields have been modified")
+	}
+}
+
+func Test_resolveArgs(t *testing.T) {
+	args := []interface{}{"foo", 1}
+
+	if result := resolveArgs(args); &result[0] != &args[0] {
+		t.Error("the args have been copied without lazy values")
+	}
+
+	args = append(args, LogValuerFunc(func() interface{} { return "bar" }))
+	result := resolveArgs(args)
+
+	if wantResult := []interface{}{"foo", 1, "bar"}; !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("args == %v, want %v", result, wantResult)
+	}
+
+	if _, ok := args[2].(LogValuer); !ok {
+		t.Error("the given args have been modified")
+	}
+}
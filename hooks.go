@@ -15,6 +15,7 @@ func newLevelHooks() *levelHooks {
 func (lh levelHooks) copy() *levelHooks {
 	lh2 := newLevelHooks()
 	lh2.errOutput = lh.errOutput
+	lh2.all = append(lh2.all, lh.all...)
 
 	for level, hooks := range lh.store {
 		lh2.store[level] = append(lh2.store[level], hooks...)
@@ -23,13 +24,15 @@ func (lh levelHooks) copy() *levelHooks {
 	return lh2
 }
 
-func (lh levelHooks) add(h Hook) error {
+func (lh *levelHooks) add(h Hook) error {
 	levels := h.Levels()
 
 	if len(levels) == 0 {
 		return ErrEmptyHookLevels
 	}
 
+	lh.all = append(lh.all, h)
+
 	for _, level := range levels {
 		lh.store[level] = append(lh.store[level], h)
 	}
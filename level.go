@@ -1,27 +1,48 @@
 package logger
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 )
 
+var customLevels = newLevelRegistry()
+
+var (
+	upperLevelNames   = [...]string{"PRINT", panicLevelStr, fatalLevelStr, errorLevelStr, warningLevelStr, infoLevelStr, debugLevelStr, traceLevelStr} // nolint:lll
+	lowerLevelNames   = [...]string{"print", "panic", "fatal", "error", "warning", "info", "debug", "trace"}
+	shortLevelNames   = [...]string{"PRNT", "PANC", "FATL", "EROR", "WARN", "INFO", "DBUG", "TRCE"}
+	numericLevelNames = [...]string{"0", "1", "2", "3", "4", "5", "6", "7"}
+)
+
 // ParseLevel returns the Level constant from the given level string.
+//
+// The names are case insensitive, and the short names and the aliases,
+// like "warn", "err" or "critical", are also accepted.
+// The registered level names take precedence over the aliases.
 func ParseLevel(levelStr string) (level Level, err error) {
-	switch strings.ToUpper(levelStr) {
-	case printLevelStr:
+	levelStr = strings.ToUpper(levelStr)
+
+	if custom, ok := customLevels.lookupName(levelStr); ok {
+		return custom, nil
+	}
+
+	switch levelStr {
+	case printLevelStr, "PRINT", "PRNT":
 		level = PRINT
-	case panicLevelStr:
+	case panicLevelStr, "PANC":
 		level = PANIC
-	case fatalLevelStr:
+	case fatalLevelStr, "FATL", "CRITICAL", "CRIT":
 		level = FATAL
-	case errorLevelStr:
+	case errorLevelStr, "EROR", "ERR":
 		level = ERROR
-	case warningLevelStr:
+	case warningLevelStr, "WARN":
 		level = WARNING
 	case infoLevelStr:
 		level = INFO
-	case debugLevelStr:
+	case debugLevelStr, "DBUG":
 		level = DEBUG
-	case traceLevelStr:
+	case traceLevelStr, "TRCE":
 		level = TRACE
 	default:
 		level = invalid
@@ -31,8 +52,159 @@ func ParseLevel(levelStr string) (level Level, err error) {
 	return level, err
 }
 
+// RegisterLevel registers a custom level with the given configuration,
+// so it could be used like the built-in ones.
+//
+// The name must not be a built-in level name or short name, or a registered one.
+// The aliases, like "critical" or "err", could be registered,
+// and then ParseLevel returns the custom level for them.
+//
+// NOTE: Register the custom levels at the initialization,
+// before using them in loggers and hooks.
+func RegisterLevel(cfg LevelConfig) (Level, error) {
+	return customLevels.register(cfg)
+}
+
+// Levels returns the built-in levels followed by the registered ones.
+func Levels() []Level {
+	levels := []Level{PRINT, PANIC, FATAL, ERROR, WARNING, INFO, DEBUG, TRACE}
+
+	for level := TRACE + 1; ; level++ {
+		if _, ok := customLevels.lookup(level); !ok {
+			break
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels
+}
+
+func newLevelRegistry() *levelRegistry {
+	r := &levelRegistry{next: TRACE + 1}
+	r.levels.Store(map[Level]*customLevel{})
+
+	return r
+}
+
+func (r *levelRegistry) lookup(level Level) (*customLevel, bool) {
+	if level <= TRACE {
+		return nil, false
+	}
+
+	custom, ok := r.levels.Load().(map[Level]*customLevel)[level] // nolint:forcetypeassert
+
+	return custom, ok
+}
+
+func (r *levelRegistry) lookupName(name string) (Level, bool) {
+	for level, custom := range r.levels.Load().(map[Level]*customLevel) { // nolint:forcetypeassert
+		if custom.cfg.Name == name {
+			return level, true
+		}
+	}
+
+	return invalid, false
+}
+
+func (r *levelRegistry) register(cfg LevelConfig) (Level, error) {
+	cfg.Name = strings.ToUpper(cfg.Name)
+
+	if cfg.Name == "" {
+		return invalid, ErrEmptyLevelName
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.lookupName(cfg.Name); ok || isBuiltinLevelName(cfg.Name) {
+		return invalid, fmt.Errorf("%w: %s", ErrLevelAlreadyRegistered, cfg.Name)
+	}
+
+	short := cfg.Name
+	if len(short) > 4 { // nolint:gomnd
+		short = short[:4]
+	}
+
+	level := r.next
+	r.next++
+
+	current := r.levels.Load().(map[Level]*customLevel) // nolint:forcetypeassert
+	levels := make(map[Level]*customLevel, len(current)+1)
+
+	for k, v := range current {
+		levels[k] = v
+	}
+
+	levels[level] = &customLevel{
+		cfg:     cfg,
+		lower:   strings.ToLower(cfg.Name),
+		short:   short,
+		numeric: strconv.FormatFloat(float64(cfg.Severity)/levelSeverityStep, 'f', -1, 64),
+	}
+
+	r.levels.Store(levels)
+
+	return level, nil
+}
+
+// isBuiltinLevelName returns whether the given uppercase name is a built-in level name or short name.
+func isBuiltinLevelName(name string) bool {
+	for i := range upperLevelNames {
+		if name == upperLevelNames[i] || name == shortLevelNames[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Enabled returns whether the given level is enabled by the level as threshold,
+// so if its severity is lower or equal.
+func (l Level) Enabled(level Level) bool {
+	if l <= TRACE && level <= TRACE {
+		return l >= level
+	}
+
+	return l.Severity() >= level.Severity()
+}
+
+// Severity returns the position of the level,
+// which decides if the level is enabled for a logger level.
+//
+// The built-in levels are spaced by 10, from PRINT (0) to TRACE (70).
+func (l Level) Severity() int {
+	if custom, ok := customLevels.lookup(l); ok {
+		return custom.cfg.Severity
+	}
+
+	return int(l) * levelSeverityStep
+}
+
+// exits returns whether the program exits after logging the level.
+func (l Level) exits() bool {
+	if custom, ok := customLevels.lookup(l); ok {
+		return custom.cfg.Exit
+	}
+
+	return l == FATAL
+}
+
+// panics returns whether it panics after logging the level.
+func (l Level) panics() bool {
+	if custom, ok := customLevels.lookup(l); ok {
+		return custom.cfg.Panic
+	}
+
+	return l == PANIC
+}
+
 // Strings returns the string representation of the level.
 func (l Level) String() string {
+	if custom, ok := customLevels.lookup(l); ok {
+		return custom.cfg.Name
+	}
+
 	switch l {
 	case PRINT:
 		return printLevelStr
@@ -56,3 +228,53 @@ func (l Level) String() string {
 		return ErrInvalidLevel.Error()
 	}
 }
+
+// format returns the name of the level with the given format,
+// and whether it's a number.
+//
+// The PRINT level name is empty, unless printLevel is true or it has a custom name.
+func (l Level) format(format LevelFormat, names map[Level]string, printLevel bool) (string, bool) {
+	if name, ok := names[l]; ok {
+		return name, false
+	}
+
+	if l == PRINT && !printLevel {
+		return "", false
+	}
+
+	if custom, ok := customLevels.lookup(l); ok {
+		switch format {
+		case LevelFormatLower:
+			return custom.lower, false
+		case LevelFormatShort:
+			return custom.short, false
+		case LevelFormatNumeric:
+			return custom.numeric, true
+		case LevelFormatUpper:
+			fallthrough
+		default:
+			return custom.cfg.Name, false
+		}
+	}
+
+	if l < PRINT || l > TRACE {
+		if format == LevelFormatNumeric {
+			return strconv.Itoa(int(l)), true
+		}
+
+		return l.String(), false
+	}
+
+	switch format {
+	case LevelFormatLower:
+		return lowerLevelNames[l], false
+	case LevelFormatShort:
+		return shortLevelNames[l], false
+	case LevelFormatNumeric:
+		return numericLevelNames[l], true
+	case LevelFormatUpper:
+		fallthrough
+	default:
+		return upperLevelNames[l], false
+	}
+}
@@ -0,0 +1,90 @@
+package logger
+
+import "io"
+
+// Enabled calls fn(level).
+func (fn LevelFilterFunc) Enabled(level Level) bool {
+	return fn(level)
+}
+
+// Enabled returns whether the given level is the exact one.
+func (f LevelExact) Enabled(level Level) bool {
+	return Level(f) == level
+}
+
+// Enabled returns whether the given level severity is inside the range.
+func (f LevelRange) Enabled(level Level) bool {
+	from, to := f.From.Severity(), f.To.Severity()
+	if from > to {
+		from, to = to, from
+	}
+
+	severity := level.Severity()
+
+	return severity >= from && severity <= to
+}
+
+// NewLevelSet creates a new level set with the given levels.
+func NewLevelSet(levels ...Level) LevelSet {
+	s := make(LevelSet, len(levels))
+
+	for _, level := range levels {
+		s[level] = struct{}{}
+	}
+
+	return s
+}
+
+// Enabled returns whether the given level is in the set.
+func (s LevelSet) Enabled(level Level) bool {
+	_, ok := s[level]
+
+	return ok
+}
+
+// FilterLevels returns the levels enabled by the given filter, from the built-in and registered ones.
+//
+// Useful to implement the Levels method of the hooks.
+func FilterLevels(filter LevelFilter) []Level {
+	levels := Levels()
+	result := levels[:0]
+
+	for _, level := range levels {
+		if filter.Enabled(level) {
+			result = append(result, level)
+		}
+	}
+
+	return result
+}
+
+// NewLevelFilterWriter creates a new writer, which only writes to w the entries enabled by the filter.
+func NewLevelFilterWriter(filter LevelFilter, w io.Writer) *LevelFilterWriter {
+	return &LevelFilterWriter{
+		filter: filter,
+		w:      w,
+	}
+}
+
+// Write writes p to the underlying writer, since the level is unknown.
+func (w *LevelFilterWriter) Write(p []byte) (int, error) {
+	return w.w.Write(p) // nolint:wrapcheck
+}
+
+// WriteLevel writes p to the underlying writer only if the level is enabled by the filter.
+func (w *LevelFilterWriter) WriteLevel(level Level, p []byte) (int, error) {
+	if !w.filter.Enabled(level) {
+		return len(p), nil
+	}
+
+	return writeLevel(w.w, level, p)
+}
+
+// writeLevel writes p to w, with WriteLevel if it's a LevelWriter.
+func writeLevel(w io.Writer, level Level, p []byte) (int, error) {
+	if lw, ok := w.(LevelWriter); ok {
+		return lw.WriteLevel(level, p) // nolint:wrapcheck
+	}
+
+	return w.Write(p) // nolint:wrapcheck
+}
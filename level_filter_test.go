@@ -0,0 +1,152 @@
+package logger
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestLevelFilters(t *testing.T) { // nolint:funlen
+	type args struct {
+		filter LevelFilter
+	}
+
+	type want struct {
+		enabled []Level
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Level",
+			args: args{
+				filter: WARNING,
+			},
+			want: want{
+				enabled: []Level{PRINT, PANIC, FATAL, ERROR, WARNING, testLevelAlert, testLevelEmerg},
+			},
+		},
+		{
+			name: "LevelExact",
+			args: args{
+				filter: LevelExact(INFO),
+			},
+			want: want{
+				enabled: []Level{INFO},
+			},
+		},
+		{
+			name: "LevelRange",
+			args: args{
+				filter: LevelRange{From: WARNING, To: ERROR},
+			},
+			want: want{
+				enabled: []Level{ERROR, WARNING},
+			},
+		},
+		{
+			name: "LevelRange reversed",
+			args: args{
+				filter: LevelRange{From: ERROR, To: WARNING},
+			},
+			want: want{
+				enabled: []Level{ERROR, WARNING},
+			},
+		},
+		{
+			name: "LevelSet",
+			args: args{
+				filter: NewLevelSet(DEBUG, PANIC),
+			},
+			want: want{
+				enabled: []Level{PANIC, DEBUG},
+			},
+		},
+		{
+			name: "LevelFilterFunc",
+			args: args{
+				filter: LevelFilterFunc(func(level Level) bool { return level == TRACE }),
+			},
+			want: want{
+				enabled: []Level{TRACE},
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Helper()
+
+			wantEnabled := NewLevelSet(test.want.enabled...)
+
+			for _, level := range Levels() {
+				_, want := wantEnabled[level]
+
+				if enabled := test.args.filter.Enabled(level); enabled != want {
+					t.Errorf("enabled(%s) == %t, want %t", level, enabled, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterLevels(t *testing.T) {
+	result := FilterLevels(LevelRange{From: INFO, To: TRACE})
+	want := []Level{INFO, DEBUG, TRACE}
+
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("levels == %v, want %v", result, want)
+	}
+}
+
+func TestLevelFilterWriter(t *testing.T) {
+	output := new(bytes.Buffer)
+	w := NewLevelFilterWriter(LevelExact(INFO), output)
+
+	if n, err := w.WriteLevel(ERROR, []byte("error\n")); err != nil || n != 6 {
+		t.Errorf("WriteLevel() == (%d, %v), want (%d, nil)", n, err, 6)
+	}
+
+	if n, err := w.WriteLevel(INFO, []byte("info\n")); err != nil || n != 5 {
+		t.Errorf("WriteLevel() == (%d, %v), want (%d, nil)", n, err, 5)
+	}
+
+	if _, err := w.Write([]byte("raw\n")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if want := "info\nraw\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestLevelFilterWriter_nested(t *testing.T) {
+	output := new(bytes.Buffer)
+	w := NewLevelFilterWriter(ERROR, NewLevelFilterWriter(LevelExact(FATAL), output))
+
+	w.WriteLevel(ERROR, []byte("error\n")) // nolint:errcheck
+	w.WriteLevel(FATAL, []byte("fatal\n")) // nolint:errcheck
+
+	if want := "fatal\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestLogger_LevelFilterWriter(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	l := New(TRACE, NewLevelFilterWriter(LevelExact(INFO), output))
+	l.SetFlags(0)
+
+	l.Error("error")
+	l.Info("info")
+
+	if want := "INFO - info\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
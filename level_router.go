@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+)
+
+// NewLevelRouter creates a new level router with the given routes.
+//
+// An entry is written to all the routes which enable its level, like:
+//
+//	logger.NewLevelRouter(
+//		logger.LevelRoute{Filter: logger.ERROR, Writer: os.Stderr},
+//		logger.LevelRoute{Filter: logger.LevelRange{From: logger.WARNING, To: logger.TRACE}, Writer: os.Stdout},
+//	)
+func NewLevelRouter(routes ...LevelRoute) *LevelRouter {
+	return &LevelRouter{
+		routes: append([]LevelRoute(nil), routes...),
+	}
+}
+
+// Write writes p to the writers of all the routes, since the level is unknown.
+func (r *LevelRouter) Write(p []byte) (int, error) {
+	var errs MultiError
+
+	for i, route := range r.routes {
+		if _, err := route.Writer.Write(p); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write route[%d]: %w", i, err))
+		}
+	}
+
+	if err := errs.err(); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// WriteLevel writes p to the writers of the routes which enable the level.
+func (r *LevelRouter) WriteLevel(level Level, p []byte) (int, error) {
+	var errs MultiError
+
+	for i, route := range r.routes {
+		if !route.Filter.Enabled(level) {
+			continue
+		}
+
+		if _, err := writeLevel(route.Writer, level, p); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write route[%d]: %w", i, err))
+		}
+	}
+
+	if err := errs.err(); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Sync flushes and syncs the writers of the routes which implement Flusher or Syncer.
+func (r *LevelRouter) Sync() error {
+	var errs MultiError
+
+	for i, route := range r.routes {
+		if err := syncOrFlush(route.Writer); err != nil && !isSyncUnsupported(err) {
+			errs = append(errs, fmt.Errorf("failed to sync route[%d]: %w", i, err))
+		}
+	}
+
+	return errs.err()
+}
+
+// Close closes the writers of the routes which implement io.Closer,
+// except the standard output and error.
+func (r *LevelRouter) Close() error {
+	var errs MultiError
+
+	for i, route := range r.routes {
+		if c, ok := route.Writer.(io.Closer); ok && !isStdOutput(route.Writer) {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close route[%d]: %w", i, err))
+			}
+		}
+	}
+
+	return errs.err()
+}
@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write(_ []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestLevelRouter_WriteLevel(t *testing.T) { // nolint:funlen
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	alerts := new(bytes.Buffer)
+
+	r := NewLevelRouter(
+		LevelRoute{Filter: ERROR, Writer: stderr},
+		LevelRoute{Filter: LevelRange{From: WARNING, To: TRACE}, Writer: stdout},
+		LevelRoute{Filter: WARNING, Writer: alerts},
+	)
+
+	type args struct {
+		level Level
+		p     string
+	}
+
+	type want struct {
+		stdout string
+		stderr string
+		alerts string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Error",
+			args: args{
+				level: ERROR,
+				p:     "error\n",
+			},
+			want: want{
+				stderr: "error\n",
+				alerts: "error\n",
+			},
+		},
+		{
+			name: "Warning",
+			args: args{
+				level: WARNING,
+				p:     "warning\n",
+			},
+			want: want{
+				stdout: "warning\n",
+				alerts: "warning\n",
+			},
+		},
+		{
+			name: "Info",
+			args: args{
+				level: INFO,
+				p:     "info\n",
+			},
+			want: want{
+				stdout: "info\n",
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			stdout.Reset()
+			stderr.Reset()
+			alerts.Reset()
+
+			n, err := r.WriteLevel(test.args.level, []byte(test.args.p))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if n != len(test.args.p) {
+				t.Errorf("n == %d, want %d", n, len(test.args.p))
+			}
+
+			if stdout.String() != test.want.stdout {
+				t.Errorf("stdout == %q, want %q", stdout.String(), test.want.stdout)
+			}
+
+			if stderr.String() != test.want.stderr {
+				t.Errorf("stderr == %q, want %q", stderr.String(), test.want.stderr)
+			}
+
+			if alerts.String() != test.want.alerts {
+				t.Errorf("alerts == %q, want %q", alerts.String(), test.want.alerts)
+			}
+		})
+	}
+}
+
+func TestLevelRouter_Write(t *testing.T) {
+	first := new(bytes.Buffer)
+	second := new(bytes.Buffer)
+
+	r := NewLevelRouter(LevelRoute{Filter: ERROR, Writer: first}, LevelRoute{Filter: INFO, Writer: second})
+
+	if _, err := r.Write([]byte("raw\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first.String() != "raw\n" || second.String() != "raw\n" {
+		t.Errorf("outputs == %q, %q, want %q", first.String(), second.String(), "raw\n")
+	}
+}
+
+func TestLevelRouter_errors(t *testing.T) {
+	errWrite := errors.New("write error")
+
+	output := &mockFlushSyncer{Writer: errWriter{err: errWrite}, err: errWrite}
+
+	r := NewLevelRouter(LevelRoute{Filter: ERROR, Writer: output}, LevelRoute{Filter: ERROR, Writer: new(bytes.Buffer)})
+
+	if n, err := r.WriteLevel(ERROR, []byte("error\n")); !errors.Is(err, errWrite) || n != 0 {
+		t.Errorf("WriteLevel() == (%d, %v), want (0, %v)", n, err, errWrite)
+	}
+
+	if _, err := r.Write([]byte("error\n")); !errors.Is(err, errWrite) {
+		t.Errorf("Write() error == %v, want %v", err, errWrite)
+	}
+
+	if err := r.Sync(); !errors.Is(err, errWrite) {
+		t.Errorf("Sync() error == %v, want %v", err, errWrite)
+	}
+
+	if err := r.Close(); !errors.Is(err, errWrite) {
+		t.Errorf("Close() error == %v, want %v", err, errWrite)
+	}
+
+	if output.closed != 1 {
+		t.Errorf("closed == %d, want %d", output.closed, 1)
+	}
+}
+
+func TestLogger_LevelRouter(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	l := New(INFO, NewLevelRouter(
+		LevelRoute{Filter: ERROR, Writer: stderr},
+		LevelRoute{Filter: LevelRange{From: WARNING, To: TRACE}, Writer: stdout},
+	))
+	l.SetFlags(0)
+
+	l.Error("error")
+	l.Info("info")
+
+	if want := "ERROR - error\n"; stderr.String() != want {
+		t.Errorf("stderr == %q, want %q", stderr.String(), want)
+	}
+
+	if want := "INFO - info\n"; stdout.String() != want {
+		t.Errorf("stdout == %q, want %q", stdout.String(), want)
+	}
+}
@@ -100,6 +100,56 @@ func Test_ParseLevel(t *testing.T) { // nolint:funlen
 				err:   nil,
 			},
 		},
+		{
+			name: "Print alias",
+			args: args{
+				levelStr: "print",
+			},
+			want: want{
+				level: PRINT,
+				err:   nil,
+			},
+		},
+		{
+			name: "Warn",
+			args: args{
+				levelStr: "warn",
+			},
+			want: want{
+				level: WARNING,
+				err:   nil,
+			},
+		},
+		{
+			name: "Err",
+			args: args{
+				levelStr: "ERR",
+			},
+			want: want{
+				level: ERROR,
+				err:   nil,
+			},
+		},
+		{
+			name: "Critical",
+			args: args{
+				levelStr: "Critical",
+			},
+			want: want{
+				level: FATAL,
+				err:   nil,
+			},
+		},
+		{
+			name: "Short",
+			args: args{
+				levelStr: "dbug",
+			},
+			want: want{
+				level: DEBUG,
+				err:   nil,
+			},
+		},
 		{
 			name: "Invalid",
 			args: args{
@@ -240,3 +290,282 @@ func TestLevel_String(t *testing.T) { // nolint:funlen
 		})
 	}
 }
+
+func TestLevel_format(t *testing.T) { // nolint:funlen
+	type args struct {
+		level      Level
+		format     LevelFormat
+		names      map[Level]string
+		printLevel bool
+	}
+
+	type want struct {
+		result  string
+		numeric bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Upper",
+			args: args{
+				level:  INFO,
+				format: LevelFormatUpper,
+			},
+			want: want{
+				result: infoLevelStr,
+			},
+		},
+		{
+			name: "Lower",
+			args: args{
+				level:  DEBUG,
+				format: LevelFormatLower,
+			},
+			want: want{
+				result: "debug",
+			},
+		},
+		{
+			name: "Short",
+			args: args{
+				level:  ERROR,
+				format: LevelFormatShort,
+			},
+			want: want{
+				result: "EROR",
+			},
+		},
+		{
+			name: "Numeric",
+			args: args{
+				level:  TRACE,
+				format: LevelFormatNumeric,
+			},
+			want: want{
+				result:  "7",
+				numeric: true,
+			},
+		},
+		{
+			name: "Names",
+			args: args{
+				level:  WARNING,
+				format: LevelFormatLower,
+				names:  map[Level]string{WARNING: "warn"},
+			},
+			want: want{
+				result: "warn",
+			},
+		},
+		{
+			name: "Print",
+			args: args{
+				level:  PRINT,
+				format: LevelFormatUpper,
+			},
+			want: want{
+				result: "",
+			},
+		},
+		{
+			name: "PrintLevel",
+			args: args{
+				level:      PRINT,
+				format:     LevelFormatUpper,
+				printLevel: true,
+			},
+			want: want{
+				result: "PRINT",
+			},
+		},
+		{
+			name: "Print with name",
+			args: args{
+				level:  PRINT,
+				format: LevelFormatUpper,
+				names:  map[Level]string{PRINT: "log"},
+			},
+			want: want{
+				result: "log",
+			},
+		},
+		{
+			name: "Invalid",
+			args: args{
+				level:  invalid,
+				format: LevelFormatLower,
+			},
+			want: want{
+				result: ErrInvalidLevel.Error(),
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Helper()
+
+			result, numeric := test.args.level.format(test.args.format, test.args.names, test.args.printLevel)
+
+			if result != test.want.result {
+				t.Errorf("result == %s, want %s", result, test.want.result)
+			}
+
+			if numeric != test.want.numeric {
+				t.Errorf("numeric == %t, want %t", numeric, test.want.numeric)
+			}
+		})
+	}
+}
+
+// resetLevelRegistry replaces the registered levels with an empty registry,
+// so the levels registered by a test don't leak to the others, and returns a function to restore them.
+func resetLevelRegistry() func() {
+	prev := customLevels
+	customLevels = newLevelRegistry()
+
+	return func() {
+		customLevels = prev
+	}
+}
+
+func TestRegisterLevel(t *testing.T) { // nolint:funlen
+	defer resetLevelRegistry()()
+
+	if _, err := RegisterLevel(LevelConfig{}); !errors.Is(err, ErrEmptyLevelName) {
+		t.Errorf("error == %v, want %v", err, ErrEmptyLevelName)
+	}
+
+	level, err := RegisterLevel(LevelConfig{Name: "audit", Severity: INFO.Severity() - 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"info", "WARN", "Audit"} {
+		if _, err := RegisterLevel(LevelConfig{Name: name}); !errors.Is(err, ErrLevelAlreadyRegistered) {
+			t.Errorf("error (%s) == %v, want %v", name, err, ErrLevelAlreadyRegistered)
+		}
+	}
+
+	if level <= TRACE {
+		t.Errorf("level == %d, want greater than %d", level, TRACE)
+	}
+
+	if parsed, err := ParseLevel("Audit"); err != nil || parsed != level {
+		t.Errorf("ParseLevel() == (%d, %v), want (%d, nil)", parsed, err, level)
+	}
+
+	if result := level.String(); result != "AUDIT" {
+		t.Errorf("String() == %s, want %s", result, "AUDIT")
+	}
+
+	if result := level.Severity(); result != INFO.Severity()-1 {
+		t.Errorf("Severity() == %d, want %d", result, INFO.Severity()-1)
+	}
+
+	found := false
+
+	for _, l := range Levels() {
+		if l == level {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("Levels() does not contain the level %s", level)
+	}
+}
+
+func TestRegisterLevel_aliases(t *testing.T) {
+	defer resetLevelRegistry()()
+
+	notice, err := RegisterLevel(LevelConfig{Name: "NOTICE", Severity: WARNING.Severity() + 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	critical, err := RegisterLevel(LevelConfig{Name: "CRITICAL", Severity: FATAL.Severity() + 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		level Level
+	}{
+		{name: "notice", level: notice},
+		{name: "critical", level: critical},
+		{name: "crit", level: FATAL},
+		{name: "fatal", level: FATAL},
+	}
+
+	for _, test := range tests {
+		if level, err := ParseLevel(test.name); err != nil || level != test.level {
+			t.Errorf("ParseLevel(%s) == (%s, %v), want (%s, nil)", test.name, level, err, test.level)
+		}
+	}
+}
+
+func TestLevel_Severity(t *testing.T) {
+	for i, level := range []Level{PRINT, PANIC, FATAL, ERROR, WARNING, INFO, DEBUG, TRACE} {
+		if result, want := level.Severity(), i*levelSeverityStep; result != want {
+			t.Errorf("Severity(%s) == %d, want %d", level, result, want)
+		}
+	}
+
+	if result := testLevelNotice.Severity(); result != WARNING.Severity()+5 {
+		t.Errorf("Severity(%s) == %d, want %d", testLevelNotice, result, WARNING.Severity()+5)
+	}
+}
+
+func TestLevel_exits_panics(t *testing.T) {
+	tests := []struct {
+		level  Level
+		exits  bool
+		panics bool
+	}{
+		{level: PANIC, panics: true},
+		{level: FATAL, exits: true},
+		{level: ERROR},
+		{level: testLevelNotice},
+		{level: testLevelAlert, exits: true},
+		{level: testLevelEmerg, panics: true},
+	}
+
+	for _, test := range tests {
+		if result := test.level.exits(); result != test.exits {
+			t.Errorf("exits(%s) == %t, want %t", test.level, result, test.exits)
+		}
+
+		if result := test.level.panics(); result != test.panics {
+			t.Errorf("panics(%s) == %t, want %t", test.level, result, test.panics)
+		}
+	}
+}
+
+func TestLevel_format_custom(t *testing.T) {
+	tests := []struct {
+		format  LevelFormat
+		result  string
+		numeric bool
+	}{
+		{format: LevelFormatUpper, result: "NOTICE"},
+		{format: LevelFormatLower, result: "notice"},
+		{format: LevelFormatShort, result: "NOTI"},
+		{format: LevelFormatNumeric, result: "4.5", numeric: true},
+	}
+
+	for _, test := range tests {
+		result, numeric := testLevelNotice.format(test.format, nil, false)
+
+		if result != test.result || numeric != test.numeric {
+			t.Errorf("format(%d) == (%s, %t), want (%s, %t)", test.format, result, numeric, test.result, test.numeric)
+		}
+	}
+}
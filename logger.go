@@ -7,7 +7,14 @@ import (
 )
 
 // New creates a new Logger.
-func New(level Level, output io.Writer, fields ...Field) *Logger {
+//
+// The level could be a Level, which enables it and the ones with lower severity,
+// or any other LevelFilter. If nil, the INFO level is used.
+func New(level LevelFilter, output io.Writer, fields ...Field) *Logger {
+	if level == nil {
+		level = defaultLevel
+	}
+
 	l := new(Logger)
 	l.level = level
 	l.output = output
@@ -15,6 +22,9 @@ func New(level Level, output io.Writer, fields ...Field) *Logger {
 		Separator: defaultTextSeparator,
 	})
 	l.hooks = newLevelHooks()
+	l.clock = defaultClock
+	l.panicMode = defaultPanicMode
+	l.exitTimeout = defaultExitTimeout
 	l.exit = os.Exit
 
 	l.setCalldepth(calldepth)
@@ -24,48 +34,193 @@ func New(level Level, output io.Writer, fields ...Field) *Logger {
 	return l
 }
 
-func (l *Logger) encodeOutput(level Level, msg string, args []interface{}) {
+// encodeOutput encodes and writes the entry if the level is enabled,
+// and returns the panic error if the level panics.
+func (l *Logger) encodeOutput(level Level, msg string, args []interface{}) *PanicError {
 	l.mu.RLock()
+	perr := l.encodeOutputDepth(l.cfg.calldepth+1, level, msg, args, nil)
+	l.mu.RUnlock()
+
+	return perr
+}
 
-	if l.isLevelEnabled(level) {
+// encodeOutputDepth is like encodeOutput, but gets the caller from the given calldepth,
+// and adds the given fields to the entry.
+//
+// NOTE: The logger must be locked.
+func (l *Logger) encodeOutputDepth(
+	calldepth int, level Level, msg string, args []interface{}, fields []Field,
+) *PanicError {
+	var perr *PanicError
+
+	enabled := l.isLevelEnabled(level)
+	record := !enabled && l.backtrace != nil && l.backtrace.records(level)
+
+	if enabled || record || level.panics() {
 		buf := AcquireBuffer()
 
-		e := Entry{
-			Config:     l.cfg,
-			Level:      level,
-			Message:    buf.formatMessage(msg, args),
-			RawMessage: msg,
-			Args:       args,
+		e := l.newEntry(buf, calldepth, level, msg, args, fields)
+		cfgFields := e.Config.Fields
+		write := enabled && processEntry(l.processors, &e, l)
+
+		if write && !sameFields(e.Config.Fields, cfgFields) {
+			l.prepareConfigFields(&e)
 		}
-		e.Caller.File = unknownFile
-		e.Caller.Line = 0
 
-		if l.cfg.Datetime || l.cfg.Timestamp {
-			e.Time = time.Now()
+		// NOTE: The panic error is built from the prepared entry too, so it gets the redacted values.
+		if record || write || level.panics() {
+			l.prepare(&e)
+		}
 
-			if l.cfg.UTC {
-				e.Time = e.Time.UTC()
+		switch {
+		case record:
+			l.record(buf, e)
+		case write:
+			if l.backtrace != nil && l.backtrace.flushes(e.Level) {
+				l.backtrace.flush(l.output)
 			}
-		}
 
-		if l.cfg.Shortfile || l.cfg.Longfile || l.cfg.Function {
-			e.Caller = getFileCaller(l.cfg.calldepth)
+			l.write(buf, e)
 		}
 
-		l.encoder.Encode(buf, e)    // nolint:errcheck
-		l.output.Write(buf.Bytes()) // nolint:errcheck
-		l.hooks.fire(e)
+		if level.panics() {
+			perr = newPanicError(e)
+		}
 
 		ReleaseBuffer(buf)
 	}
 
+	return perr
+}
+
+// prepare resolves the lazy fields added by the processors and redacts the entry,
+// before writing or recording it.
+//
+// NOTE: The logger must be locked.
+func (l *Logger) prepare(e *Entry) {
+	e.Fields = resolveFields(e.Fields)
+
+	if l.redactor != nil {
+		l.redactor.redactEntry(e)
+	}
+}
+
+// prepareConfigFields resolves and redacts the logger fields replaced by a processor,
+// so they are encoded with the entry instead of the cached ones.
+//
+// NOTE: The logger must be locked.
+func (l *Logger) prepareConfigFields(e *Entry) {
+	e.Config.Fields = resolveFields(e.Config.Fields)
+
+	if l.redactor != nil {
+		e.Config.Fields = l.redactor.redactFields(e.Config.Fields)
+	}
+
+	e.Config.lazy = true
+}
+
+// write encodes and writes the prepared entry, and fires the hooks.
+//
+// NOTE: The logger must be locked.
+func (l *Logger) write(buf *Buffer, e Entry) {
+	l.encoder.Encode(buf, e)                   // nolint:errcheck
+	writeLevel(l.output, e.Level, buf.Bytes()) // nolint:errcheck
+	l.hooks.fire(e)
+}
+
+// record encodes the prepared entry, disabled by the logger level, into the backtrace.
+//
+// NOTE: The logger must be locked.
+func (l *Logger) record(buf *Buffer, e Entry) {
+	l.encoder.Encode(buf, e) // nolint:errcheck
+	l.backtrace.add(e.Level, buf.Bytes())
+}
+
+// writeEntry encodes and writes the entry emitted by a processor while processing.
+//
+// NOTE: The logger must be locked.
+func (l *Logger) writeEntry(e Entry) {
+	// The entry could come from a logger copy with other fields,
+	// so they are encoded with the entry instead of the encoded ones.
+	e.Config.lazy = true
+
+	l.prepare(&e)
+
+	buf := AcquireBuffer()
+	l.write(buf, e)
+	ReleaseBuffer(buf)
+}
+
+// emit encodes and writes the entry emitted by a processor out of the processing.
+func (l *Logger) emit(e Entry) {
+	l.mu.RLock()
+	l.writeEntry(e)
 	l.mu.RUnlock()
 }
 
+// newEntry returns a new entry with the logger configuration.
+//
+// NOTE: It must be called from encodeOutputDepth, to get the right caller.
+func (l *Logger) newEntry(
+	buf *Buffer, calldepth int, level Level, msg string, args []interface{}, fields []Field,
+) Entry {
+	args = resolveArgs(args)
+
+	if l.redactor != nil {
+		args = l.redactor.redactArgs(args)
+	}
+
+	e := Entry{
+		Config:     l.cfg,
+		Level:      level,
+		Message:    buf.formatMessage(msg, args),
+		RawMessage: msg,
+		Args:       args,
+		Fields:     resolveFields(fields),
+	}
+	e.Caller.File = unknownFile
+	e.Caller.Line = 0
+
+	// NOTE: The logger fields are capped, so the processors appending to them do not overwrite the shared ones.
+	e.Config.Fields = l.cfg.Fields[:len(l.cfg.Fields):len(l.cfg.Fields)]
+
+	if l.cfg.lazy {
+		e.Config.Fields = resolveFields(l.cfg.Fields)
+
+		if l.redactor != nil {
+			e.Config.Fields = l.redactor.redactFields(e.Config.Fields)
+		}
+	}
+
+	if l.cfg.Datetime || l.cfg.Timestamp {
+		e.Time = l.clock.Now()
+
+		if l.cfg.Location != nil {
+			e.Time = e.Time.In(l.cfg.Location)
+		} else if l.cfg.UTC {
+			e.Time = e.Time.UTC()
+		}
+	}
+
+	if l.cfg.Shortfile || l.cfg.Longfile || l.cfg.Function || level.panics() {
+		caller := getFileCaller(buf.callerPCs(), calldepth+1)
+
+		e.Caller = caller.frame
+		e.callerShortFile = caller.shortFile
+	}
+
+	return e
+}
+
+// getField returns the field with the given key of the current namespace.
 func (l *Logger) getField(key string) *Field {
-	for i := range l.cfg.Fields {
+	for i := len(l.cfg.Fields) - 1; i >= 0; i-- {
 		field := &l.cfg.Fields[i]
 
+		if field.typ == fieldTypeNamespace {
+			break
+		}
+
 		if field.Key == key {
 			return field
 		}
@@ -80,18 +235,36 @@ func (l *Logger) setCalldepth(value int) {
 
 func (l *Logger) setFields(fields ...Field) {
 	for _, field := range fields {
-		if optField := l.getField(field.Key); optField != nil {
-			optField.Value = field.Value
+		if l.redactor != nil {
+			field, _ = l.redactor.redactField(field)
+		}
+
+		if optField := l.getField(field.Key); optField != nil && field.typ != fieldTypeNamespace {
+			*optField = field
 		} else {
 			l.cfg.Fields = append(l.cfg.Fields, field)
 		}
 	}
 
+	l.cfg.lazy = hasLazyFields(l.cfg.Fields)
 	l.encoder.Configure(l.cfg)
 }
 
 func (l *Logger) isLevelEnabled(level Level) bool {
-	return l.level >= level
+	return l.level.Enabled(level)
+}
+
+// terminate panics or exits after logging the given level, if required.
+func (l *Logger) terminate(level Level, perr *PanicError) {
+	if level.panics() {
+		l.flush()
+
+		panic(l.panicValue(perr))
+	}
+
+	if level.exits() {
+		l.exitWithCode(1)
+	}
 }
 
 func (l *Logger) copy() *Logger {
@@ -101,7 +274,13 @@ func (l *Logger) copy() *Logger {
 	l2.output = l.output
 	l2.encoder = l.encoder.Copy()
 	l2.hooks = l.hooks.copy()
+	l2.processors = append(l2.processors, l.processors...)
+	l2.redactor = l.redactor
+	l2.clock = l.clock
+	l2.panicMode = l.panicMode
+	l2.exitTimeout = l.exitTimeout
 	l2.exit = l.exit
+	l2.backtrace = l.backtrace
 
 	return l2
 }
@@ -118,6 +297,41 @@ func (l *Logger) WithFields(fields ...Field) *Logger {
 	return l2
 }
 
+// WithCallerSkip returns a logger copy which skips n more frames when getting the caller,
+// so the wrappers of the logger methods report the file and function of their callers.
+//
+// Use Helper instead to skip the wrappers wherever they are called from.
+func (l *Logger) WithCallerSkip(n int) *Logger {
+	l.mu.RLock()
+
+	l2 := l.copy()
+	l2.setCalldepth(l.cfg.calldepth + n)
+
+	l.mu.RUnlock()
+
+	return l2
+}
+
+// WithBacktrace returns a logger copy which records the disabled entries into the given backtrace,
+// like the logger of a request. The copies of the returned logger share it.
+//
+// If nil, the entries are not recorded.
+func (l *Logger) WithBacktrace(b *Backtrace) *Logger {
+	l.mu.RLock()
+
+	l2 := l.copy()
+	l2.backtrace = b
+
+	l.mu.RUnlock()
+
+	return l2
+}
+
+// WithGroup returns a logger copy whose subsequent fields are nested under the given name.
+func (l *Logger) WithGroup(name string) *Logger {
+	return l.WithFields(Namespace(name))
+}
+
 // SetFields sets the logger fields.
 func (l *Logger) SetFields(fields ...Field) {
 	l.mu.Lock()
@@ -140,8 +354,23 @@ func (l *Logger) SetFlags(flag Flag) {
 	l.mu.Unlock()
 }
 
-// SetLevel sets the logger level.
-func (l *Logger) SetLevel(level Level) {
+// SetLocation sets the time zone of the datetime and timestamp.
+//
+// If nil, the LUTC flag decides between UTC and the local time zone.
+func (l *Logger) SetLocation(loc *time.Location) {
+	l.mu.Lock()
+	l.cfg.Location = loc
+	l.mu.Unlock()
+}
+
+// SetLevel sets the logger level, or any other level filter.
+//
+// If nil, the INFO level is used.
+func (l *Logger) SetLevel(level LevelFilter) {
+	if level == nil {
+		level = defaultLevel
+	}
+
 	l.mu.Lock()
 	l.level = level
 	l.mu.Unlock()
@@ -154,6 +383,52 @@ func (l *Logger) SetOutput(output io.Writer) {
 	l.mu.Unlock()
 }
 
+// SetClock sets the clock used to get the entries time.
+//
+// If nil, the system clock is used.
+func (l *Logger) SetClock(clock Clock) {
+	if clock == nil {
+		clock = defaultClock
+	}
+
+	l.mu.Lock()
+	l.clock = clock
+	l.mu.Unlock()
+}
+
+// SetPanicMode sets the value of the panics raised by the PANIC entries.
+func (l *Logger) SetPanicMode(mode PanicMode) {
+	if mode == 0 {
+		mode = defaultPanicMode
+	}
+
+	l.mu.Lock()
+	l.panicMode = mode
+	l.mu.Unlock()
+}
+
+// SetExitTimeout sets the maximum time to run the exit handlers and flush the output and hooks,
+// before exiting by a FATAL entry.
+//
+// A zero or negative timeout waits for them without limit.
+//
+// Default: 5s
+func (l *Logger) SetExitTimeout(timeout time.Duration) {
+	l.mu.Lock()
+	l.exitTimeout = timeout
+	l.mu.Unlock()
+}
+
+// SetBacktrace sets the backtrace which records the entries disabled by the logger level,
+// and writes them to the output before an error, or any other flush level.
+//
+// If nil, the entries are not recorded.
+func (l *Logger) SetBacktrace(b *Backtrace) {
+	l.mu.Lock()
+	l.backtrace = b
+	l.mu.Unlock()
+}
+
 // SetEncoder sets the logger encoder.
 func (l *Logger) SetEncoder(enc Encoder) {
 	l.mu.Lock()
@@ -162,6 +437,22 @@ func (l *Logger) SetEncoder(enc Encoder) {
 	l.mu.Unlock()
 }
 
+// SetRedactor sets the logger redactor.
+//
+// The fields already set are also redacted.
+func (l *Logger) SetRedactor(r *Redactor) {
+	l.mu.Lock()
+
+	l.redactor = r
+
+	if r != nil {
+		l.cfg.Fields = r.redactFields(l.cfg.Fields)
+		l.encoder.Configure(l.cfg)
+	}
+
+	l.mu.Unlock()
+}
+
 // IsLevelEnabled checks if the given level is enabled on the logger.
 func (l *Logger) IsLevelEnabled(level Level) bool {
 	l.mu.RLock()
@@ -176,6 +467,21 @@ func (l *Logger) AddHook(h Hook) error {
 	return l.hooks.add(h)
 }
 
+// AddProcessor registers the given processor to the logger.
+//
+// The processors are run in the same order as registered.
+//
+// If it's an EmitProcessor, the entries emitted out of the processing are written by this logger.
+func (l *Logger) AddProcessor(p Processor) {
+	if ep, ok := p.(EmitProcessor); ok {
+		ep.SetEmit(l.emit)
+	}
+
+	l.mu.Lock()
+	l.processors = append(l.processors, p)
+	l.mu.Unlock()
+}
+
 func (l *Logger) Print(msg ...interface{}) {
 	l.encodeOutput(PRINT, "", msg)
 }
@@ -184,24 +490,54 @@ func (l *Logger) Printf(msg string, args ...interface{}) {
 	l.encodeOutput(PRINT, msg, args)
 }
 
+// Log logs with the given level, like a custom one.
+func (l *Logger) Log(level Level, msg ...interface{}) {
+	l.terminate(level, l.encodeOutput(level, "", msg))
+}
+
+// Logf logs with the given level and format, like a custom one.
+func (l *Logger) Logf(level Level, msg string, args ...interface{}) {
+	l.terminate(level, l.encodeOutput(level, msg, args))
+}
+
+// LogFields logs the message with the given level, and the given fields only for this entry.
+//
+// It's cheaper than WithFields for the fields which change on every entry,
+// since the logger is not copied and its fields are not encoded again.
+func (l *Logger) LogFields(level Level, msg string, fields ...Field) {
+	l.mu.RLock()
+	perr := l.encodeOutputDepth(l.cfg.calldepth, level, msg, nil, fields)
+	l.mu.RUnlock()
+
+	l.terminate(level, perr)
+}
+
 func (l *Logger) Panic(msg ...interface{}) {
-	l.encodeOutput(PANIC, "", msg)
-	panic(l)
+	l.terminate(PANIC, l.encodeOutput(PANIC, "", msg))
 }
 
 func (l *Logger) Panicf(msg string, args ...interface{}) {
-	l.encodeOutput(PANIC, msg, args)
-	panic(l)
+	l.terminate(PANIC, l.encodeOutput(PANIC, msg, args))
 }
 
 func (l *Logger) Fatal(msg ...interface{}) {
-	l.encodeOutput(FATAL, "", msg)
-	l.exit(1)
+	l.terminate(FATAL, l.encodeOutput(FATAL, "", msg))
 }
 
 func (l *Logger) Fatalf(msg string, args ...interface{}) {
+	l.terminate(FATAL, l.encodeOutput(FATAL, msg, args))
+}
+
+// FatalWithCode is like Fatal, but exits with the given code.
+func (l *Logger) FatalWithCode(code int, msg ...interface{}) {
+	l.encodeOutput(FATAL, "", msg)
+	l.exitWithCode(code)
+}
+
+// FatalfWithCode is like Fatalf, but exits with the given code.
+func (l *Logger) FatalfWithCode(code int, msg string, args ...interface{}) {
 	l.encodeOutput(FATAL, msg, args)
-	l.exit(1)
+	l.exitWithCode(code)
 }
 
 func (l *Logger) Error(msg ...interface{}) {
@@ -3,19 +3,41 @@ package logger
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 var levels = []Level{PRINT, FATAL, ERROR, WARNING, INFO, DEBUG, TRACE}
 
+var (
+	testLevelNotice = mustRegisterLevel(LevelConfig{Name: "notice", Severity: WARNING.Severity() + 5})
+	testLevelAlert  = mustRegisterLevel(LevelConfig{Name: "alert", Severity: FATAL.Severity() + 5, Exit: true})
+	testLevelEmerg  = mustRegisterLevel(LevelConfig{Name: "emerg", Severity: PANIC.Severity() + 5, Panic: true})
+)
+
+func mustRegisterLevel(cfg LevelConfig) Level {
+	level, err := RegisterLevel(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	return level
+}
+
 type testLoggerLevelArgs struct {
 	fn  func(msg ...interface{})
 	fnf func(msg string, args ...interface{})
+
+	// log and logf are used instead of fn and fnf, with the wanted level.
+	log  func(level Level, msg ...interface{})
+	logf func(level Level, msg string, args ...interface{})
 }
 
 type testLoggerLevelWant struct {
@@ -50,7 +72,7 @@ func assertEncoder(t *testing.T, cfg Config, enc Encoder) {
 func Test_New(t *testing.T) {
 	level := INFO
 	output := os.Stderr
-	fields := []Field{{"key", "value"}}
+	fields := []Field{{Key: "key", Value: "value"}}
 
 	l := New(level, output, fields...)
 
@@ -73,6 +95,10 @@ func Test_New(t *testing.T) {
 		t.Errorf("Logger.output == %p, want %p", l.output, output)
 	}
 
+	if l2 := New(nil, output); l2.level != defaultLevel {
+		t.Errorf("Logger.level == %v, want %d", l2.level, defaultLevel)
+	}
+
 	if l.encoder == nil {
 		t.Fatal("Logger.enconder is nil")
 	}
@@ -83,6 +109,10 @@ func Test_New(t *testing.T) {
 
 	assertEncoder(t, l.cfg, l.encoder)
 
+	if _, ok := l.clock.(ClockFunc); !ok {
+		t.Errorf("Logger.clock == %T, want %T", l.clock, defaultClock)
+	}
+
 	loggerExitPtr := reflect.ValueOf(l.exit).Pointer()
 	osExitPtr := reflect.ValueOf(os.Exit).Pointer()
 
@@ -203,6 +233,130 @@ func TestLogger_encodeOutput(t *testing.T) { // nolint:funlen
 	}
 }
 
+func TestLogger_encodeOutput_processors(t *testing.T) { // nolint:funlen
+	output := new(bytes.Buffer)
+
+	l := newTestLogger()
+	l.SetOutput(output)
+	l.SetFlags(0)
+	l.SetFields()
+
+	l.AddProcessor(ProcessorFunc(func(e *Entry) bool {
+		if e.RawMessage == "drop" {
+			return false
+		}
+
+		e.Level = WARNING
+		e.Message = "processed " + e.Message
+		e.Fields = append(e.Fields, Field{Key: "host", Value: "localhost"})
+
+		return true
+	}))
+
+	var hookEntry Entry
+
+	hook := &testHook{
+		levels: []Level{WARNING},
+		fireFunc: func(e Entry) error {
+			hookEntry = e
+
+			return nil
+		},
+	}
+
+	if err := l.AddHook(hook); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	l.encodeOutput(INFO, "hello", nil)
+
+	wantOutput := "WARNING - url=GET \"https://example.com\" - host=localhost - processed hello\n"
+	if result := output.String(); result != wantOutput {
+		t.Errorf("output == %q, want %q", result, wantOutput)
+	}
+
+	if hookEntry.Message != "processed hello" {
+		t.Errorf("hook entry message == %s, want %s", hookEntry.Message, "processed hello")
+	}
+
+	if len(hookEntry.Fields) != 1 {
+		t.Errorf("hook entry fields == %d, want %d", len(hookEntry.Fields), 1)
+	}
+
+	output.Reset()
+
+	hookEntry = Entry{}
+
+	l.encodeOutput(INFO, "drop", nil)
+
+	if output.Len() > 0 {
+		t.Errorf("dropped entry has been written: %s", output.String())
+	}
+
+	if hookEntry.Message != "" {
+		t.Error("hook fired with a dropped entry")
+	}
+}
+
+func TestLogger_encodeOutput_lazy(t *testing.T) { // nolint:funlen
+	encoders := map[string]struct {
+		enc  Encoder
+		want string
+	}{
+		"text": {
+			enc:  NewEncoderText(EncoderTextConfig{}),
+			want: "INFO - url=GET \"https://example.com\" - body=data 2 - hello data 1\n",
+		},
+		"json": {
+			enc:  NewEncoderJSON(EncoderJSONConfig{}),
+			want: `{"level":"INFO","url":"GET \"https://example.com\"","body":"data 2","message":"hello data 1"}` + "\n",
+		},
+	}
+
+	for name, test := range encoders {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			output := new(bytes.Buffer)
+			calls := 0
+
+			newValue := func() interface{} {
+				calls++
+
+				return fmt.Sprintf("data %d", calls)
+			}
+
+			l := newTestLogger()
+			l.SetOutput(output)
+			l.SetFlags(0)
+			l.SetEncoder(test.enc)
+			l.SetLevel(INFO)
+
+			l = l.WithFields(Lazy("body", newValue))
+
+			if !l.cfg.lazy {
+				t.Fatal("config is not lazy")
+			}
+
+			l.Debug("hello ", LogValuerFunc(newValue))
+
+			if calls != 0 {
+				t.Errorf("lazy values evaluated with a disabled level: %d", calls)
+			}
+
+			l.Info("hello ", LogValuerFunc(newValue))
+
+			if calls != 2 {
+				t.Errorf("calls == %d, want %d", calls, 2)
+			}
+
+			if result := output.String(); result != test.want {
+				t.Errorf("output == %s, want %s", result, test.want)
+			}
+		})
+	}
+}
+
 func TestLogger_getField(t *testing.T) {
 	field := Field{Key: "key", Value: "value"}
 
@@ -220,6 +374,29 @@ func TestLogger_getField(t *testing.T) {
 	}
 }
 
+func TestLogger_getField_namespace(t *testing.T) {
+	l := newTestLogger()
+	l.SetFields(String("method", "POST"), Namespace("http"))
+
+	if field := l.getField("method"); field != nil {
+		t.Errorf("field of other namespace found: %v", field)
+	}
+
+	l.SetFields(String("method", "GET"), String("method", "PUT"))
+
+	if totalFields := len(l.cfg.Fields); totalFields != 4 {
+		t.Errorf("fields == %d, want %d", totalFields, 4)
+	}
+
+	if field := l.getField("method"); field == nil || field.str != "PUT" {
+		t.Errorf("field == %v, want %s", field, "PUT")
+	}
+
+	if l.cfg.Fields[1].str != "POST" {
+		t.Errorf("field of other namespace updated: %v", l.cfg.Fields[1])
+	}
+}
+
 func TestLogger_setCalldepth(t *testing.T) {
 	testCalldepth := 123
 
@@ -232,8 +409,8 @@ func TestLogger_setCalldepth(t *testing.T) {
 }
 
 func TestLogger_setFields(t *testing.T) { // nolint:funlen
-	field1 := Field{"key", "value"}
-	field2 := Field{"foo", []int{1, 2, 3}}
+	field1 := Field{Key: "key", Value: "value"}
+	field2 := Field{Key: "foo", Value: []int{1, 2, 3}}
 
 	type args struct {
 		fields []Field
@@ -263,7 +440,7 @@ func TestLogger_setFields(t *testing.T) { // nolint:funlen
 		{
 			name: "update",
 			args: args{
-				fields: []Field{{field1.Key, 123.45}},
+				fields: []Field{{Key: field1.Key, Value: 123.45}},
 			},
 			want: want{
 				totalFields: 2,
@@ -272,7 +449,7 @@ func TestLogger_setFields(t *testing.T) { // nolint:funlen
 		{
 			name: "append",
 			args: args{
-				fields: []Field{{"data", []interface{}{1, "2", nil}}},
+				fields: []Field{{Key: "data", Value: []interface{}{1, "2", nil}}},
 			},
 			want: want{
 				totalFields: 3,
@@ -320,9 +497,41 @@ func TestLogger_isLevelEnabled(t *testing.T) {
 	}
 }
 
+func TestLogger_isLevelEnabled_custom(t *testing.T) {
+	l := newTestLogger()
+
+	tests := []struct {
+		level   Level
+		current Level
+		want    bool
+	}{
+		{level: WARNING, current: testLevelNotice, want: false},
+		{level: INFO, current: testLevelNotice, want: true},
+		{level: testLevelNotice, current: WARNING, want: true},
+		{level: testLevelNotice, current: INFO, want: false},
+		{level: testLevelNotice, current: testLevelNotice, want: true},
+		{level: ERROR, current: testLevelAlert, want: true},
+		{level: PANIC, current: testLevelAlert, want: false},
+	}
+
+	for _, test := range tests {
+		l.SetLevel(test.level)
+
+		if enabled := l.isLevelEnabled(test.current); enabled != test.want {
+			t.Errorf("enabled (level: %s, current: %s) == %t, want %t", test.level, test.current, enabled, test.want)
+		}
+	}
+}
+
 func TestLogger_copy(t *testing.T) {
 	l1 := newTestLogger()
 	l1.SetOutput(new(bytes.Buffer))
+	l1.AddProcessor(ProcessorFunc(func(_ *Entry) bool { return true }))
+	l1.SetRedactor(NewRedactor(RedactorConfig{}))
+	l1.SetClock(NewFakeClock(time.Now()))
+	l1.SetPanicMode(PanicModeMessage)
+	l1.SetExitTimeout(time.Second)
+	l1.SetBacktrace(NewBacktrace(BacktraceConfig{}))
 
 	l2 := l1.copy()
 
@@ -366,6 +575,34 @@ func TestLogger_copy(t *testing.T) {
 		t.Error("hooks has the same pointer")
 	}
 
+	if l2.redactor != l1.redactor {
+		t.Errorf("redactor == %p, want %p", l2.redactor, l1.redactor)
+	}
+
+	if l2.exitTimeout != l1.exitTimeout {
+		t.Errorf("exitTimeout == %s, want %s", l2.exitTimeout, l1.exitTimeout)
+	}
+
+	if l2.panicMode != l1.panicMode {
+		t.Errorf("panicMode == %d, want %d", l2.panicMode, l1.panicMode)
+	}
+
+	if l2.clock != l1.clock {
+		t.Errorf("clock == %p, want %p", l2.clock, l1.clock)
+	}
+
+	if l2.backtrace != l1.backtrace {
+		t.Errorf("backtrace == %p, want %p", l2.backtrace, l1.backtrace)
+	}
+
+	if len(l2.processors) != len(l1.processors) {
+		t.Errorf("processors == %d, want %d", len(l2.processors), len(l1.processors))
+	}
+
+	if &l2.processors[0] == &l1.processors[0] {
+		t.Error("processors has the same pointer")
+	}
+
 	l1ExitPtr := reflect.ValueOf(l1.exit).Pointer()
 	l2ExitPtr := reflect.ValueOf(l2.exit).Pointer()
 
@@ -377,7 +614,7 @@ func TestLogger_copy(t *testing.T) {
 func testLoggerWithFields(t *testing.T, l1 *Logger, withFieldsFunc func(fields ...Field) *Logger) {
 	t.Helper()
 
-	l2 := withFieldsFunc(Field{"key", "value"})
+	l2 := withFieldsFunc(Field{Key: "key", Value: "value"})
 
 	l1TotalFields := len(l1.cfg.Fields)
 	l2TotalFields := len(l2.cfg.Fields)
@@ -392,10 +629,104 @@ func TestLogger_WithFields(t *testing.T) {
 	testLoggerWithFields(t, l1, l1.WithFields)
 }
 
+func testLoggerInfoWrapper(l *Logger, msg string) {
+	l.Info(msg)
+}
+
+func testLoggerCallerEntry(l *Logger, fn func()) Entry {
+	var entry Entry
+
+	l.SetOutput(io.Discard)
+	l.SetFlags(Lshortfile | Lfunction)
+	l.AddProcessor(ProcessorFunc(func(e *Entry) bool {
+		entry = *e
+
+		return true
+	}))
+
+	fn()
+
+	return entry
+}
+
+func testLoggerWithCallerSkip(t *testing.T, l1 *Logger, withCallerSkipFunc func(n int) *Logger) {
+	t.Helper()
+
+	l1Calldepth := l1.cfg.calldepth
+
+	l2 := withCallerSkipFunc(1)
+
+	if l2.cfg.calldepth != calldepth+1 {
+		t.Errorf("calldepth == %d, want %d", l2.cfg.calldepth, calldepth+1)
+	}
+
+	if l1.cfg.calldepth != l1Calldepth {
+		t.Errorf("original calldepth == %d, want %d", l1.cfg.calldepth, l1Calldepth)
+	}
+
+	entry := testLoggerCallerEntry(l2, func() {
+		testLoggerInfoWrapper(l2, "hello")
+	})
+
+	wantFunction := "github.com/savsgio/go-logger/v4.testLoggerWithCallerSkip.func1"
+	if entry.Caller.Function != wantFunction {
+		t.Errorf("caller function == %s, want %s", entry.Caller.Function, wantFunction)
+	}
+}
+
+func TestLogger_WithCallerSkip(t *testing.T) {
+	l := newTestLogger()
+	testLoggerWithCallerSkip(t, l, l.WithCallerSkip)
+}
+
+func testLoggerWithBacktrace(t *testing.T, l1 *Logger, withBacktraceFunc func(b *Backtrace) *Logger) {
+	t.Helper()
+
+	b := NewBacktrace(BacktraceConfig{})
+
+	l2 := withBacktraceFunc(b)
+
+	if l2.backtrace != b {
+		t.Errorf("backtrace == %p, want %p", l2.backtrace, b)
+	}
+
+	if l1.backtrace != nil {
+		t.Errorf("original backtrace == %p, want nil", l1.backtrace)
+	}
+
+	if l3 := l2.WithFields(String("foo", "bar")); l3.backtrace != b {
+		t.Errorf("derived backtrace == %p, want %p", l3.backtrace, b)
+	}
+}
+
+func TestLogger_WithBacktrace(t *testing.T) {
+	l := newTestLogger()
+	testLoggerWithBacktrace(t, l, l.WithBacktrace)
+}
+
+func testLoggerWithGroup(t *testing.T, l1 *Logger, withGroupFunc func(name string) *Logger) {
+	t.Helper()
+
+	l2 := withGroupFunc("http")
+
+	if l2TotalFields, wantTotalFields := len(l2.cfg.Fields), len(l1.cfg.Fields)+1; l2TotalFields != wantTotalFields {
+		t.Fatalf("fields == %d, want %d", l2TotalFields, wantTotalFields)
+	}
+
+	if field := l2.cfg.Fields[len(l2.cfg.Fields)-1]; field.typ != fieldTypeNamespace || field.Key != "http" {
+		t.Errorf("field == %v, want namespace %s", field, "http")
+	}
+}
+
+func TestLogger_WithGroup(t *testing.T) {
+	l1 := newTestLogger()
+	testLoggerWithGroup(t, l1, l1.WithGroup)
+}
+
 func testLoggerSetFields(t *testing.T, l *Logger, setFieldsFunc func(fields ...Field)) {
 	t.Helper()
 
-	fields := []Field{{"key", "value"}, {"foo", "bar"}}
+	fields := []Field{{Key: "key", Value: "value"}, {Key: "foo", Value: "bar"}}
 
 	beforeTotalFields := len(l.cfg.Fields)
 
@@ -556,7 +887,7 @@ func TestLogger_SetFlags(t *testing.T) {
 	testLoggerSetFlags(t, l, l.SetFlags)
 }
 
-func testLoggerSetLevel(t *testing.T, l *Logger, setLevelFunc func(level Level)) {
+func testLoggerSetLevel(t *testing.T, l *Logger, setLevelFunc func(level LevelFilter)) {
 	t.Helper()
 
 	level := DEBUG
@@ -564,7 +895,25 @@ func testLoggerSetLevel(t *testing.T, l *Logger, setLevelFunc func(level Level))
 	setLevelFunc(level)
 
 	if l.level != level {
-		t.Errorf("level == %d, want %d", l.level, level)
+		t.Errorf("level == %v, want %d", l.level, level)
+	}
+
+	filter := NewLevelSet(ERROR, INFO)
+
+	setLevelFunc(filter)
+
+	if l.isLevelEnabled(WARNING) {
+		t.Errorf("level %s is enabled", WARNING)
+	}
+
+	if !l.isLevelEnabled(INFO) {
+		t.Errorf("level %s is not enabled", INFO)
+	}
+
+	setLevelFunc(nil)
+
+	if l.level != defaultLevel {
+		t.Errorf("level == %v, want %d", l.level, defaultLevel)
 	}
 }
 
@@ -573,6 +922,45 @@ func TestLogger_SetLevel(t *testing.T) {
 	testLoggerSetLevel(t, l, l.SetLevel)
 }
 
+func testLoggerSetLocation(t *testing.T, l *Logger, setLocationFunc func(loc *time.Location)) {
+	t.Helper()
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+
+	setLocationFunc(loc)
+
+	if l.cfg.Location != loc {
+		t.Errorf("location == %v, want %v", l.cfg.Location, loc)
+	}
+
+	var entryTime time.Time
+
+	l.SetOutput(io.Discard)
+	l.SetFlags(Ldatetime | LUTC)
+	l.AddProcessor(ProcessorFunc(func(e *Entry) bool {
+		entryTime = e.Time
+
+		return true
+	}))
+	l.Info("hello")
+
+	if entryTime.Location() != loc {
+		t.Errorf("entry location == %v, want %v", entryTime.Location(), loc)
+	}
+
+	setLocationFunc(nil)
+	l.Info("hello")
+
+	if entryTime.Location() != time.UTC {
+		t.Errorf("entry location == %v, want %v", entryTime.Location(), time.UTC)
+	}
+}
+
+func TestLogger_SetLocation(t *testing.T) {
+	l := newTestLogger()
+	testLoggerSetLocation(t, l, l.SetLocation)
+}
+
 func testLoggerSetOutput(t *testing.T, l *Logger, setOutputFunc func(output io.Writer)) {
 	t.Helper()
 
@@ -590,6 +978,196 @@ func TestLogger_SetOutput(t *testing.T) {
 	testLoggerSetOutput(t, l, l.SetOutput)
 }
 
+func testLoggerSetClock(t *testing.T, l *Logger, setClockFunc func(clock Clock)) {
+	t.Helper()
+
+	clock := NewFakeClock(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	setClockFunc(clock)
+
+	if l.clock != clock {
+		t.Errorf("clock == %p, want %p", l.clock, clock)
+	}
+
+	var entryTime time.Time
+
+	l.SetOutput(io.Discard)
+	l.SetFlags(Ldatetime)
+	l.AddProcessor(ProcessorFunc(func(e *Entry) bool {
+		entryTime = e.Time
+
+		return true
+	}))
+	l.Info("hello")
+
+	if !entryTime.Equal(clock.Now()) {
+		t.Errorf("entry time == %s, want %s", entryTime, clock.Now())
+	}
+
+	setClockFunc(nil)
+
+	if _, ok := l.clock.(ClockFunc); !ok {
+		t.Errorf("clock == %T, want %T", l.clock, defaultClock)
+	}
+}
+
+func TestLogger_SetClock(t *testing.T) {
+	l := newTestLogger()
+	testLoggerSetClock(t, l, l.SetClock)
+}
+
+func testLoggerSetBacktrace(t *testing.T, l *Logger, setBacktraceFunc func(b *Backtrace)) {
+	t.Helper()
+
+	output := new(bytes.Buffer)
+
+	l.SetOutput(output)
+	l.SetFlags(0)
+	l.SetLevel(INFO)
+
+	b := NewBacktrace(BacktraceConfig{})
+
+	setBacktraceFunc(b)
+
+	if l.backtrace != b {
+		t.Errorf("backtrace == %p, want %p", l.backtrace, b)
+	}
+
+	l.Debug("debug")
+	l.Info("info")
+
+	if strings.Contains(output.String(), "debug") {
+		t.Errorf("output == %q, want without the debug entry", output.String())
+	}
+
+	l.Error("error")
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	wantPrefixes := []string{"INFO", "DEBUG", "ERROR"}
+
+	if len(lines) != len(wantPrefixes) {
+		t.Fatalf("lines == %q, want %d", lines, len(wantPrefixes))
+	}
+
+	for i, prefix := range wantPrefixes {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line[%d] == %q, want prefix %q", i, lines[i], prefix)
+		}
+	}
+
+	setBacktraceFunc(nil)
+
+	if l.backtrace != nil {
+		t.Errorf("backtrace == %p, want nil", l.backtrace)
+	}
+}
+
+func TestLogger_SetBacktrace(t *testing.T) {
+	l := newTestLogger()
+	testLoggerSetBacktrace(t, l, l.SetBacktrace)
+}
+
+func testLoggerSetPanicMode(t *testing.T, l *Logger, setPanicModeFunc func(mode PanicMode)) {
+	t.Helper()
+
+	l.SetOutput(io.Discard)
+
+	tests := []struct {
+		mode PanicMode
+		want func(recv interface{}) bool
+	}{
+		{
+			mode: PanicModeMessage,
+			want: func(recv interface{}) bool { return recv == "hello" },
+		},
+		{
+			mode: PanicModeLogger,
+			want: func(recv interface{}) bool { return recv == l },
+		},
+		{
+			mode: 0,
+			want: func(recv interface{}) bool {
+				_, ok := recv.(*PanicError)
+
+				return ok
+			},
+		},
+	}
+
+	for _, test := range tests {
+		setPanicModeFunc(test.mode)
+
+		func() {
+			defer func() {
+				if recv := recover(); !test.want(recv) {
+					t.Errorf("panic value (mode: %d) == %v", test.mode, recv)
+				}
+			}()
+
+			l.Panic("hello")
+		}()
+	}
+}
+
+func TestLogger_SetPanicMode(t *testing.T) {
+	l := newTestLogger()
+	testLoggerSetPanicMode(t, l, l.SetPanicMode)
+}
+
+func testLoggerSetExitTimeout(t *testing.T, l *Logger, setExitTimeoutFunc func(timeout time.Duration)) {
+	t.Helper()
+
+	timeout := time.Second
+
+	setExitTimeoutFunc(timeout)
+
+	if l.exitTimeout != timeout {
+		t.Errorf("exitTimeout == %s, want %s", l.exitTimeout, timeout)
+	}
+}
+
+func TestLogger_SetExitTimeout(t *testing.T) {
+	l := newTestLogger()
+	testLoggerSetExitTimeout(t, l, l.SetExitTimeout)
+}
+
+func testLoggerFatalWithCode(
+	t *testing.T, l *Logger,
+	fatalFunc func(code int, msg ...interface{}), fatalfFunc func(code int, msg string, args ...interface{}),
+) {
+	t.Helper()
+
+	output := new(bytes.Buffer)
+
+	exitCode := -1
+	l.exit = func(code int) { exitCode = code }
+
+	l.SetOutput(output)
+	l.SetFlags(0)
+	l.SetFields()
+
+	fatalFunc(2, "hello")
+
+	if exitCode != 2 {
+		t.Errorf("exit code == %d, want %d", exitCode, 2)
+	}
+
+	fatalfFunc(3, "hello %s", "world")
+
+	if exitCode != 3 {
+		t.Errorf("exit code == %d, want %d", exitCode, 3)
+	}
+
+	if want := "FATAL - hello\nFATAL - hello world\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestLogger_FatalWithCode(t *testing.T) {
+	l := New(INFO, io.Discard)
+	testLoggerFatalWithCode(t, l, l.FatalWithCode, l.FatalfWithCode)
+}
+
 func testLoggerSetEncoder(t *testing.T, l *Logger, setEncoderFunc func(enc Encoder)) {
 	t.Helper()
 
@@ -609,6 +1187,31 @@ func TestLogger_SetEncoder(t *testing.T) {
 	testLoggerSetEncoder(t, l, l.SetEncoder)
 }
 
+func testLoggerSetRedactor(t *testing.T, l *Logger, setRedactorFunc func(r *Redactor)) {
+	t.Helper()
+
+	l.SetFields(Field{Key: "password", Value: "1234"})
+
+	r := NewRedactor(RedactorConfig{Keys: []string{"password"}})
+
+	setRedactorFunc(r)
+
+	if l.redactor != r {
+		t.Errorf("redactor == %p, want %p", l.redactor, r)
+	}
+
+	if field := l.getField("password"); field.Value != r.cfg.Replacement {
+		t.Errorf("field value == %v, want %s", field.Value, r.cfg.Replacement)
+	}
+
+	assertEncoder(t, l.cfg, l.encoder)
+}
+
+func TestLogger_SetRedactor(t *testing.T) {
+	l := newTestLogger()
+	testLoggerSetRedactor(t, l, l.SetRedactor)
+}
+
 func testLoggerIsLevelEnabled(t *testing.T, l *Logger, isLevelEnabledFunc func(level Level) bool) {
 	t.Helper()
 
@@ -692,6 +1295,110 @@ func TestLogger_AddHook(t *testing.T) {
 	testLoggerAddHook(t, l, l.AddHook)
 }
 
+func testLoggerLogFields(t *testing.T, l *Logger, logFieldsFunc func(level Level, msg string, fields ...Field)) {
+	t.Helper()
+
+	cfgFields := l.cfg.Fields
+
+	entry := testLoggerCallerEntry(l, func() {
+		logFieldsFunc(WARNING, "hello %s", String("foo", "bar"), Int("n", 1))
+	})
+
+	if entry.Level != WARNING || entry.Message != "hello %s" {
+		t.Errorf("entry == %s %q, want %s %q", entry.Level, entry.Message, WARNING, "hello %s")
+	}
+
+	if wantFields := []Field{String("foo", "bar"), Int("n", 1)}; !reflect.DeepEqual(entry.Fields, wantFields) {
+		t.Errorf("entry fields == %v, want %v", entry.Fields, wantFields)
+	}
+
+	if !reflect.DeepEqual(l.cfg.Fields, cfgFields) {
+		t.Errorf("logger fields == %v, want %v", l.cfg.Fields, cfgFields)
+	}
+
+	wantFunction := "github.com/savsgio/go-logger/v4.testLoggerLogFields.func1"
+	if entry.Caller.Function != wantFunction {
+		t.Errorf("caller function == %s, want %s", entry.Caller.Function, wantFunction)
+	}
+
+	output := new(bytes.Buffer)
+
+	l.SetOutput(output)
+	l.SetFlags(0)
+
+	logFieldsFunc(INFO, "hello", String("foo", "bar"))
+
+	if want := " - foo=bar - hello\n"; !strings.HasSuffix(output.String(), want) {
+		t.Errorf("output == %q, want suffix %q", output.String(), want)
+	}
+}
+
+func TestLogger_LogFields(t *testing.T) {
+	l := newTestLogger()
+	testLoggerLogFields(t, l, l.LogFields)
+}
+
+func testLoggerAddProcessor(t *testing.T, l *Logger, addProcessorFunc func(p Processor)) {
+	t.Helper()
+
+	beforeTotalProcessors := len(l.processors)
+
+	addProcessorFunc(ProcessorFunc(func(_ *Entry) bool { return true }))
+
+	if afterTotalProcessors := len(l.processors); afterTotalProcessors != beforeTotalProcessors+1 {
+		t.Errorf("processors == %d, want %d", afterTotalProcessors, beforeTotalProcessors+1)
+	}
+}
+
+func TestLogger_AddProcessor(t *testing.T) {
+	l := newTestLogger()
+	testLoggerAddProcessor(t, l, l.AddProcessor)
+}
+
+func TestLogger_AddProcessor_configFields(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	l := New(INFO, output, String("token", "abc"), String("user", "bob"))
+
+	l.AddProcessor(ProcessorFunc(func(e *Entry) bool {
+		if e.Message == "append" {
+			e.Config.Fields = append(e.Config.Fields, String("extra", "1"))
+
+			return true
+		}
+
+		fields := make([]Field, len(e.Config.Fields))
+		copy(fields, e.Config.Fields)
+
+		for i := range fields {
+			if fields[i].Key == "token" {
+				fields[i] = String("token", "xxx")
+			}
+		}
+
+		e.Config.Fields = fields
+
+		return true
+	}))
+
+	l.Info("replace")
+
+	if want := "token=xxx - user=bob - replace\n"; !strings.HasSuffix(output.String(), want) {
+		t.Errorf("output == %q, want suffix %q", output.String(), want)
+	}
+
+	output.Reset()
+	l.Info("append")
+
+	if want := "token=abc - user=bob - extra=1 - append\n"; !strings.HasSuffix(output.String(), want) {
+		t.Errorf("output == %q, want suffix %q", output.String(), want)
+	}
+
+	if wantFields := []Field{String("token", "abc"), String("user", "bob")}; !reflect.DeepEqual(l.cfg.Fields, wantFields) {
+		t.Errorf("logger fields == %v, want %v", l.cfg.Fields, wantFields)
+	}
+}
+
 func testLoggerLevels(t *testing.T, l *Logger, testCases []testLoggerLevelCase) { // nolint:funlen
 	t.Helper()
 
@@ -756,13 +1463,28 @@ func testLoggerLevels(t *testing.T, l *Logger, testCases []testLoggerLevelCase)
 			return
 		}
 
-		if want.level != PANIC {
+		if !want.level.panics() {
 			t.Errorf("panic raised with level: %s", want.level)
 		}
 
-		if reflect.ValueOf(recv).Pointer() != reflect.ValueOf(l).Pointer() {
-			t.Errorf("panic value == %p, want %p", recv, l)
+		perr, ok := recv.(*PanicError)
+		if !ok {
+			t.Fatalf("panic value == %T, want %T", recv, perr)
+		}
+
+		if perr.Level != want.level {
+			t.Errorf("panic level == %s, want %s", perr.Level, want.level)
+		}
+
+		if perr.Message != entry.Message {
+			t.Errorf("panic message == %s, want %s", perr.Message, entry.Message)
 		}
+
+		if perr.Caller != entry.Caller {
+			t.Errorf("panic caller == %v, want %v", perr.Caller, entry.Caller)
+		}
+
+		entry = Entry{}
 	}
 
 	for i := range testCases {
@@ -778,7 +1500,12 @@ func testLoggerLevels(t *testing.T, l *Logger, testCases []testLoggerLevelCase)
 			msg := ""
 			args := []interface{}{"Hello", "world"}
 
-			test.args.fn(args...)
+			if test.args.log != nil {
+				test.args.log(test.want.level, args...)
+			} else {
+				test.args.fn(args...)
+			}
+
 			assert(msg, args, test.want)
 		})
 
@@ -790,7 +1517,12 @@ func testLoggerLevels(t *testing.T, l *Logger, testCases []testLoggerLevelCase)
 			msg := "Hello %s"
 			args := []interface{}{"world"}
 
-			test.args.fnf(msg, args...)
+			if test.args.logf != nil {
+				test.args.logf(test.want.level, msg, args...)
+			} else {
+				test.args.fnf(msg, args...)
+			}
+
 			assert(msg, args, test.want)
 		})
 	}
@@ -888,6 +1620,50 @@ func TestLogger_Levels(t *testing.T) { // nolint:funlen
 				exitCode: -1,
 			},
 		},
+		{
+			name: "Log",
+			args: testLoggerLevelArgs{
+				log:  l.Log,
+				logf: l.Logf,
+			},
+			want: testLoggerLevelWant{
+				level:    testLevelNotice,
+				exitCode: -1,
+			},
+		},
+		{
+			name: "LogExit",
+			args: testLoggerLevelArgs{
+				log:  l.Log,
+				logf: l.Logf,
+			},
+			want: testLoggerLevelWant{
+				level:    testLevelAlert,
+				exitCode: 1,
+			},
+		},
+		{
+			name: "LogPanic",
+			args: testLoggerLevelArgs{
+				log:  l.Log,
+				logf: l.Logf,
+			},
+			want: testLoggerLevelWant{
+				level:    testLevelEmerg,
+				exitCode: -1,
+			},
+		},
+		{
+			name: "LogBuiltin",
+			args: testLoggerLevelArgs{
+				log:  l.Log,
+				logf: l.Logf,
+			},
+			want: testLoggerLevelWant{
+				level:    INFO,
+				exitCode: -1,
+			},
+		},
 	}
 
 	testLoggerLevels(t, l, testCases)
@@ -911,6 +1687,43 @@ func BenchmarkLogger_encodeOutput(b *testing.B) { // nolint:funlen
 	})
 }
 
+func BenchmarkLogger_encodeOutput_caller(b *testing.B) {
+	l := newTestLogger()
+	l.SetFields()
+	l.SetFlags(Lshortfile | Lfunction)
+
+	b.Run("lineal", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			l.encodeOutput(DEBUG, "hello world", nil)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		b.ReportAllocs()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				l.encodeOutput(DEBUG, "hello world", nil)
+			}
+		})
+	})
+
+	// NOTE: The helpers can't be unmarked, so it must be the last one.
+	b.Run("parallel with helpers", func(b *testing.B) {
+		helpers.mark(testCachedFrameHelperPC())
+
+		b.ReportAllocs()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				l.encodeOutput(DEBUG, "hello world", nil)
+			}
+		})
+	})
+}
+
 func BenchmarkLogger_Levels(b *testing.B) { // nolint:funlen
 	l := newTestLogger()
 	l.SetEncoder(newTestEncoderJSON())
@@ -0,0 +1,9 @@
+package logrsink
+
+const (
+	nameKey  = "logger"
+	errorKey = "error"
+)
+
+// noValue is the value of the keys without value.
+const noValue = "<no-value>"
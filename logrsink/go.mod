@@ -0,0 +1,10 @@
+module github.com/savsgio/go-logger/v4/logrsink
+
+go 1.13
+
+require (
+	github.com/go-logr/logr v1.2.4
+	github.com/savsgio/go-logger/v4 v4.0.0-00010101000000-000000000000
+)
+
+replace github.com/savsgio/go-logger/v4 => ../
@@ -0,0 +1,111 @@
+package logrsink
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	logger "github.com/savsgio/go-logger/v4"
+)
+
+// New returns a logr.Logger backed by the given logger.
+func New(l *logger.Logger) logr.Logger {
+	return logr.New(NewSink(l))
+}
+
+// NewSink creates a new logr sink backed by the given logger.
+//
+// The V-levels are mapped to INFO (0), DEBUG (1) and TRACE (2 or more),
+// the key-value pairs to fields, and the names to the "logger" field, joined by "/".
+func NewSink(l *logger.Logger) *Sink {
+	return &Sink{l: l.WithCallerSkip(1)}
+}
+
+// Init skips the frames of the logr.Logger when getting the caller.
+func (s *Sink) Init(info logr.RuntimeInfo) {
+	s.l = s.l.WithCallerSkip(info.CallDepth)
+}
+
+// Enabled returns whether the level of the given V-level is enabled on the logger.
+func (s *Sink) Enabled(level int) bool {
+	return s.l.IsLevelEnabled(vLevel(level))
+}
+
+// Info logs with the level of the given V-level, and the key-value pairs as entry fields.
+func (s *Sink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.l.LogFields(vLevel(level), msg, toFields(keysAndValues)...)
+}
+
+// Error logs with the ERROR level, and the error and the key-value pairs as entry fields.
+func (s *Sink) Error(err error, msg string, keysAndValues ...interface{}) {
+	fields := toFields(keysAndValues)
+
+	if err != nil {
+		fields = append([]logger.Field{logger.Any(errorKey, err)}, fields...)
+	}
+
+	s.l.LogFields(logger.ERROR, msg, fields...)
+}
+
+// WithValues returns a sink copy with the key-value pairs as logger fields.
+func (s *Sink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	if len(keysAndValues) == 0 {
+		return &Sink{l: s.l, name: s.name}
+	}
+
+	return &Sink{l: s.l.WithFields(toFields(keysAndValues)...), name: s.name}
+}
+
+// WithName returns a sink copy with the given name appended to the "logger" field.
+func (s *Sink) WithName(name string) logr.LogSink {
+	if s.name != "" {
+		name = s.name + "/" + name
+	}
+
+	return &Sink{l: s.l.WithFields(logger.String(nameKey, name)), name: name}
+}
+
+// WithCallDepth returns a sink copy which skips depth more frames when getting the caller.
+func (s *Sink) WithCallDepth(depth int) logr.LogSink {
+	return &Sink{l: s.l.WithCallerSkip(depth), name: s.name}
+}
+
+// vLevel returns the logger level of the given V-level.
+func vLevel(level int) logger.Level {
+	switch {
+	case level <= 0:
+		return logger.INFO
+	case level == 1:
+		return logger.DEBUG
+	default:
+		return logger.TRACE
+	}
+}
+
+// toFields returns the fields of the key-value pairs.
+//
+// The keys which are not strings are formatted, and the last key without value gets "<no-value>".
+func toFields(keysAndValues []interface{}) []logger.Field {
+	fields := make([]logger.Field, 0, (len(keysAndValues)+1)/2) // nolint:gomnd
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+
+		var value interface{} = noValue
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+
+		if m, ok := value.(logr.Marshaler); ok {
+			fields = append(fields, logger.Lazy(key, m.MarshalLog))
+
+			continue
+		}
+
+		fields = append(fields, logger.Any(key, value))
+	}
+
+	return fields
+}
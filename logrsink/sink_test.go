@@ -0,0 +1,267 @@
+package logrsink
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	logger "github.com/savsgio/go-logger/v4"
+	"github.com/savsgio/go-logger/v4/logtest"
+)
+
+type testMarshaler struct {
+	id int
+}
+
+func (m testMarshaler) MarshalLog() interface{} {
+	return map[string]int{"id": m.id}
+}
+
+func newTestLogr(level logger.LevelFilter) (logr.Logger, *logtest.Recorder) {
+	l, r := logtest.New(level)
+	l.SetFlags(logger.Lshortfile | logger.Lfunction)
+
+	return New(l), r
+}
+
+func Test_vLevel(t *testing.T) {
+	type args struct {
+		level int
+	}
+
+	type want struct {
+		level logger.Level
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Negative",
+			args: args{level: -1},
+			want: want{level: logger.INFO},
+		},
+		{
+			name: "Zero",
+			args: args{level: 0},
+			want: want{level: logger.INFO},
+		},
+		{
+			name: "One",
+			args: args{level: 1},
+			want: want{level: logger.DEBUG},
+		},
+		{
+			name: "Two",
+			args: args{level: 2},
+			want: want{level: logger.TRACE},
+		},
+		{
+			name: "More",
+			args: args{level: 10},
+			want: want{level: logger.TRACE},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			if level := vLevel(test.args.level); level != test.want.level {
+				t.Errorf("level == %s, want %s", level, test.want.level)
+			}
+		})
+	}
+}
+
+func Test_toFields(t *testing.T) {
+	type args struct {
+		keysAndValues []interface{}
+	}
+
+	type want struct {
+		context map[string]interface{}
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Empty",
+			args: args{},
+			want: want{context: map[string]interface{}{}},
+		},
+		{
+			name: "Pairs",
+			args: args{keysAndValues: []interface{}{"foo", "bar", "n", 1}},
+			want: want{context: map[string]interface{}{"foo": "bar", "n": 1}},
+		},
+		{
+			name: "NoValue",
+			args: args{keysAndValues: []interface{}{"foo", "bar", "baz"}},
+			want: want{context: map[string]interface{}{"foo": "bar", "baz": noValue}},
+		},
+		{
+			name: "NotStringKey",
+			args: args{keysAndValues: []interface{}{1, "bar"}},
+			want: want{context: map[string]interface{}{"1": "bar"}},
+		},
+		{
+			name: "Marshaler",
+			args: args{keysAndValues: []interface{}{"obj", testMarshaler{id: 5}}},
+			want: want{context: map[string]interface{}{"obj": map[string]int{"id": 5}}},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			e := logtest.LoggedEntry{Context: toFields(test.args.keysAndValues)}
+
+			for i := range e.Context {
+				if lv, ok := e.Context[i].Value.(logger.LogValuer); ok {
+					e.Context[i] = logger.Any(e.Context[i].Key, lv.LogValue())
+				}
+			}
+
+			if context := e.ContextMap(); !reflect.DeepEqual(context, test.want.context) {
+				t.Errorf("context == %v, want %v", context, test.want.context)
+			}
+		})
+	}
+}
+
+func TestSink_Enabled(t *testing.T) {
+	log, _ := newTestLogr(logger.DEBUG)
+
+	if !log.V(0).Enabled() {
+		t.Error("V(0) is disabled")
+	}
+
+	if !log.V(1).Enabled() {
+		t.Error("V(1) is disabled")
+	}
+
+	if log.V(2).Enabled() {
+		t.Error("V(2) is enabled")
+	}
+}
+
+func TestSink_Info(t *testing.T) {
+	log, r := newTestLogr(logger.DEBUG)
+
+	log.Info("hello", "foo", "bar")
+	log.V(1).Info("details")
+	log.V(2).Info("disabled")
+
+	entries := r.All()
+
+	if len(entries) != 2 {
+		t.Fatalf("entries == %d, want %d", len(entries), 2)
+	}
+
+	if entries[0].Level != logger.INFO || entries[0].Message != "hello" {
+		t.Errorf("entry == %s %q, want %s %q", entries[0].Level, entries[0].Message, logger.INFO, "hello")
+	}
+
+	if want := map[string]interface{}{"foo": "bar"}; !reflect.DeepEqual(entries[0].ContextMap(), want) {
+		t.Errorf("context == %v, want %v", entries[0].ContextMap(), want)
+	}
+
+	if entries[1].Level != logger.DEBUG || entries[1].Message != "details" {
+		t.Errorf("entry == %s %q, want %s %q", entries[1].Level, entries[1].Message, logger.DEBUG, "details")
+	}
+}
+
+func TestSink_Error(t *testing.T) {
+	log, r := newTestLogr(logger.INFO)
+
+	err := errors.New("boom")
+
+	log.Error(err, "failed", "id", 1)
+	log.Error(nil, "failed without error")
+
+	entries := r.All()
+
+	if len(entries) != 2 {
+		t.Fatalf("entries == %d, want %d", len(entries), 2)
+	}
+
+	if entries[0].Level != logger.ERROR || entries[0].Message != "failed" {
+		t.Errorf("entry == %s %q, want %s %q", entries[0].Level, entries[0].Message, logger.ERROR, "failed")
+	}
+
+	if want := map[string]interface{}{errorKey: err, "id": 1}; !reflect.DeepEqual(entries[0].ContextMap(), want) {
+		t.Errorf("context == %v, want %v", entries[0].ContextMap(), want)
+	}
+
+	if len(entries[1].Context) != 0 {
+		t.Errorf("context == %v, want empty", entries[1].ContextMap())
+	}
+}
+
+func TestSink_WithValues(t *testing.T) {
+	log, r := newTestLogr(logger.INFO)
+
+	log.WithValues("foo", "bar").Info("hello", "n", 1)
+	log.Info("without values")
+
+	entries := r.All()
+
+	if want := map[string]interface{}{"foo": "bar", "n": 1}; !reflect.DeepEqual(entries[0].ContextMap(), want) {
+		t.Errorf("context == %v, want %v", entries[0].ContextMap(), want)
+	}
+
+	if len(entries[1].Context) != 0 {
+		t.Errorf("context == %v, want empty", entries[1].ContextMap())
+	}
+}
+
+func TestSink_WithName(t *testing.T) {
+	log, r := newTestLogr(logger.INFO)
+
+	log.WithName("controller").Info("hello")
+	log.WithName("controller").WithName("pod").Info("hello")
+
+	entries := r.All()
+
+	if want := map[string]interface{}{nameKey: "controller"}; !reflect.DeepEqual(entries[0].ContextMap(), want) {
+		t.Errorf("context == %v, want %v", entries[0].ContextMap(), want)
+	}
+
+	if want := map[string]interface{}{nameKey: "controller/pod"}; !reflect.DeepEqual(entries[1].ContextMap(), want) {
+		t.Errorf("context == %v, want %v", entries[1].ContextMap(), want)
+	}
+}
+
+func logrWrapper(log logr.Logger, msg string) {
+	log.WithCallDepth(1).Info(msg)
+}
+
+func TestSink_caller(t *testing.T) {
+	log, r := newTestLogr(logger.INFO)
+
+	log.Info("hello")
+	log.Error(nil, "failed")
+	log.WithValues("foo", "bar").WithName("test").Info("hello")
+	logrWrapper(log, "wrapped")
+
+	wantFunction := "github.com/savsgio/go-logger/v4/logrsink.TestSink_caller"
+
+	for _, e := range r.All() {
+		if e.Caller.Function != wantFunction {
+			t.Errorf("%q caller function == %s, want %s", e.Message, e.Caller.Function, wantFunction)
+		}
+
+		if !strings.HasSuffix(e.Caller.File, "sink_test.go") {
+			t.Errorf("%q caller file == %s, want %s", e.Message, e.Caller.File, "sink_test.go")
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package logrsink
+
+import (
+	logger "github.com/savsgio/go-logger/v4"
+)
+
+// Sink is a logr.LogSink backed by a logger, so the logr output flows through
+// its encoder, processors and hooks.
+type Sink struct {
+	l    *logger.Logger
+	name string
+}
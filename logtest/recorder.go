@@ -0,0 +1,186 @@
+package logtest
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	logger "github.com/savsgio/go-logger/v4"
+)
+
+// New creates a new logger with the given level, which only records the entries
+// to the returned recorder.
+func New(level logger.LevelFilter, fields ...logger.Field) (*logger.Logger, *Recorder) {
+	r := NewRecorder()
+
+	l := logger.New(level, io.Discard, fields...)
+	l.AddHook(r) // nolint:errcheck
+
+	return l, r
+}
+
+// NewRecorder creates a new recorder.
+//
+// Use it as a hook to record the entries of any logger.
+func NewRecorder() *Recorder {
+	return new(Recorder)
+}
+
+// Levels returns all the levels, so all the entries are recorded.
+//
+// NOTE: The custom levels must be registered before adding the recorder as hook.
+func (r *Recorder) Levels() []logger.Level {
+	return logger.Levels()
+}
+
+// Fire records the given entry.
+func (r *Recorder) Fire(e logger.Entry) error {
+	le := LoggedEntry{
+		Entry:   e,
+		Context: make([]logger.Field, 0, len(e.Config.Fields)+len(e.Fields)),
+	}
+	le.Context = append(le.Context, e.Config.Fields...)
+	le.Context = append(le.Context, e.Fields...)
+
+	r.mu.Lock()
+	r.entries = append(r.entries, le)
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Len returns the number of recorded entries.
+func (r *Recorder) Len() int {
+	r.mu.RLock()
+	n := len(r.entries)
+	r.mu.RUnlock()
+
+	return n
+}
+
+// All returns a copy of all the recorded entries.
+func (r *Recorder) All() LoggedEntries {
+	r.mu.RLock()
+	entries := make(LoggedEntries, len(r.entries))
+	copy(entries, r.entries)
+	r.mu.RUnlock()
+
+	return entries
+}
+
+// TakeAll returns all the recorded entries and resets the recorder.
+func (r *Recorder) TakeAll() LoggedEntries {
+	r.mu.Lock()
+	entries := r.entries
+	r.entries = nil
+	r.mu.Unlock()
+
+	return entries
+}
+
+// Reset removes all the recorded entries.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.entries = nil
+	r.mu.Unlock()
+}
+
+// ContextMap returns the context fields as a map,
+// where the keys of the nested fields are joined with dots.
+func (e LoggedEntry) ContextMap() map[string]interface{} {
+	result := make(map[string]interface{}, len(e.Context))
+	namespace := ""
+
+	for _, field := range e.Context {
+		if field.IsNamespace() {
+			namespace += field.Key + "."
+
+			continue
+		}
+
+		result[namespace+field.Key] = field.Interface()
+	}
+
+	return result
+}
+
+// Len returns the number of entries.
+func (es LoggedEntries) Len() int {
+	return len(es)
+}
+
+// Filter returns the entries which match the given function.
+func (es LoggedEntries) Filter(fn func(e LoggedEntry) bool) LoggedEntries {
+	result := make(LoggedEntries, 0)
+
+	for _, e := range es {
+		if fn(e) {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// FilterLevel returns the entries with the given level.
+func (es LoggedEntries) FilterLevel(level logger.Level) LoggedEntries {
+	return es.Filter(func(e LoggedEntry) bool {
+		return e.Level == level
+	})
+}
+
+// FilterMessage returns the entries with the given message.
+func (es LoggedEntries) FilterMessage(msg string) LoggedEntries {
+	return es.Filter(func(e LoggedEntry) bool {
+		return e.Message == msg
+	})
+}
+
+// FilterMessageSnippet returns the entries whose message contains the given snippet.
+func (es LoggedEntries) FilterMessageSnippet(snippet string) LoggedEntries {
+	return es.Filter(func(e LoggedEntry) bool {
+		return strings.Contains(e.Message, snippet)
+	})
+}
+
+// FilterFieldKey returns the entries with a field with the given key.
+func (es LoggedEntries) FilterFieldKey(key string) LoggedEntries {
+	return es.Filter(func(e LoggedEntry) bool {
+		for _, field := range e.Context {
+			if field.Key == key && !field.IsNamespace() {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// FilterField returns the entries with a field with the same key and value of the given one.
+func (es LoggedEntries) FilterField(field logger.Field) LoggedEntries {
+	value := field.Interface()
+
+	return es.Filter(func(e LoggedEntry) bool {
+		for _, ctxField := range e.Context {
+			if ctxField.Key == field.Key && reflect.DeepEqual(ctxField.Interface(), value) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// AssertCount reports an error to the given test if the number of entries is not the wanted one.
+func (es LoggedEntries) AssertCount(tb testing.TB, want int) bool {
+	tb.Helper()
+
+	if n := len(es); n != want {
+		tb.Errorf("logged entries == %d, want %d", n, want)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,200 @@
+package logtest
+
+import (
+	"reflect"
+	"testing"
+
+	logger "github.com/savsgio/go-logger/v4"
+)
+
+func TestNew(t *testing.T) {
+	l, r := New(logger.INFO, logger.String("service", "api"))
+
+	l.Info("hello")
+	l.Debug("ignored")
+	l.WithFields(logger.Int("code", 500)).Error("failed")
+
+	if n := r.Len(); n != 2 {
+		t.Fatalf("Len() == %d, want %d", n, 2)
+	}
+
+	entries := r.All()
+
+	if entries[0].Level != logger.INFO || entries[0].Message != "hello" {
+		t.Errorf("entry == %s %s, want %s %s", entries[0].Level, entries[0].Message, logger.INFO, "hello")
+	}
+
+	wantCtx := map[string]interface{}{"service": "api", "code": int64(500)}
+	if ctx := entries[1].ContextMap(); !reflect.DeepEqual(ctx, wantCtx) {
+		t.Errorf("ContextMap() == %v, want %v", ctx, wantCtx)
+	}
+}
+
+func TestRecorder_TakeAll(t *testing.T) {
+	l, r := New(logger.INFO)
+
+	l.Info("first")
+	l.Info("second")
+
+	if entries := r.TakeAll(); len(entries) != 2 {
+		t.Errorf("TakeAll() == %d entries, want %d", len(entries), 2)
+	}
+
+	if n := r.Len(); n != 0 {
+		t.Errorf("Len() == %d, want %d", n, 0)
+	}
+
+	l.Info("third")
+	r.Reset()
+
+	if n := r.Len(); n != 0 {
+		t.Errorf("Len() == %d, want %d", n, 0)
+	}
+}
+
+func TestRecorder_Fire(t *testing.T) {
+	l, r := New(logger.INFO, logger.String("key", "old"))
+
+	l.Info("message")
+	l.SetFields(logger.String("key", "new"))
+
+	ctx := r.All()[0].ContextMap()
+	if value := ctx["key"]; value != "old" {
+		t.Errorf("recorded field == %v, want %v", value, "old")
+	}
+}
+
+func TestLoggedEntry_ContextMap(t *testing.T) {
+	e := LoggedEntry{
+		Context: []logger.Field{
+			logger.String("a", "1"),
+			logger.Namespace("http"),
+			logger.Int("status", 200),
+			logger.Bool("ok", true),
+		},
+	}
+
+	want := map[string]interface{}{"a": "1", "http.status": int64(200), "http.ok": true}
+	if ctx := e.ContextMap(); !reflect.DeepEqual(ctx, want) {
+		t.Errorf("ContextMap() == %v, want %v", ctx, want)
+	}
+}
+
+func TestLoggedEntries_Filter(t *testing.T) { // nolint:funlen
+	l, r := New(logger.TRACE)
+
+	l.Info("request started")
+	l.WithFields(logger.Int("status", 200)).Info("request finished")
+	l.WithFields(logger.Int("status", 500)).Error("request failed")
+	l.Debug("cache miss")
+
+	entries := r.All()
+
+	type args struct {
+		entries LoggedEntries
+	}
+
+	type want struct {
+		count int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Level",
+			args: args{
+				entries: entries.FilterLevel(logger.INFO),
+			},
+			want: want{
+				count: 2,
+			},
+		},
+		{
+			name: "Message",
+			args: args{
+				entries: entries.FilterMessage("cache miss"),
+			},
+			want: want{
+				count: 1,
+			},
+		},
+		{
+			name: "MessageSnippet",
+			args: args{
+				entries: entries.FilterMessageSnippet("request"),
+			},
+			want: want{
+				count: 3,
+			},
+		},
+		{
+			name: "FieldKey",
+			args: args{
+				entries: entries.FilterFieldKey("status"),
+			},
+			want: want{
+				count: 2,
+			},
+		},
+		{
+			name: "Field",
+			args: args{
+				entries: entries.FilterField(logger.Int("status", 500)),
+			},
+			want: want{
+				count: 1,
+			},
+		},
+		{
+			name: "Chained",
+			args: args{
+				entries: entries.FilterMessageSnippet("request").FilterLevel(logger.ERROR),
+			},
+			want: want{
+				count: 1,
+			},
+		},
+		{
+			name: "None",
+			args: args{
+				entries: entries.FilterMessage("unknown"),
+			},
+			want: want{
+				count: 0,
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Helper()
+
+			if n := test.args.entries.Len(); n != test.want.count {
+				t.Errorf("Len() == %d, want %d", n, test.want.count)
+			}
+
+			if !test.args.entries.AssertCount(t, test.want.count) {
+				t.Errorf("AssertCount() == false, want true")
+			}
+		})
+	}
+}
+
+func TestLoggedEntries_AssertCount(t *testing.T) {
+	tb := new(testTB)
+
+	entries := LoggedEntries{{}, {}}
+
+	if entries.AssertCount(tb, 1) {
+		t.Errorf("AssertCount() == true, want false")
+	}
+
+	if len(tb.errors) != 1 {
+		t.Errorf("errors == %d, want %d", len(tb.errors), 1)
+	}
+}
@@ -0,0 +1,31 @@
+package logtest
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	logger "github.com/savsgio/go-logger/v4"
+)
+
+// NewTB creates a new logger with the given level, which writes the output
+// to the given test with tb.Log, so it's only shown when the test fails or in verbose mode.
+//
+// NOTE: The writer is marked as helper, but tb.Log attributes the lines to the logger internals,
+// so enable the logger.Lshortfile flag to get the caller in each line.
+func NewTB(tb testing.TB, level logger.LevelFilter, fields ...logger.Field) *logger.Logger {
+	return logger.New(level, NewTBWriter(tb), fields...)
+}
+
+// NewTBWriter returns a writer which writes each line to the given test with tb.Log.
+func NewTBWriter(tb testing.TB) io.Writer {
+	return &tbWriter{tb: tb}
+}
+
+func (w *tbWriter) Write(p []byte) (int, error) {
+	w.tb.Helper()
+
+	w.tb.Log(strings.TrimSuffix(string(p), "\n"))
+
+	return len(p), nil
+}
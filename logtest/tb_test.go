@@ -0,0 +1,68 @@
+package logtest
+
+import (
+	"fmt"
+	"testing"
+
+	logger "github.com/savsgio/go-logger/v4"
+)
+
+type testTB struct {
+	testing.TB
+
+	logs    []string
+	errors  []string
+	helpers int
+}
+
+func (tb *testTB) Helper() {
+	tb.helpers++
+}
+
+func (tb *testTB) Log(args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprint(args...))
+}
+
+func (tb *testTB) Errorf(format string, args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewTB(t *testing.T) {
+	tb := new(testTB)
+
+	l := NewTB(tb, logger.INFO)
+	l.SetFlags(0)
+
+	l.Info("hello")
+	l.Debug("ignored")
+
+	if len(tb.logs) != 1 {
+		t.Fatalf("logs == %d, want %d", len(tb.logs), 1)
+	}
+
+	if want := "INFO - hello"; tb.logs[0] != want {
+		t.Errorf("log == %q, want %q", tb.logs[0], want)
+	}
+}
+
+func TestNewTBWriter(t *testing.T) {
+	tb := new(testTB)
+	w := NewTBWriter(tb)
+
+	n, err := w.Write([]byte("line\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n != 5 {
+		t.Errorf("n == %d, want %d", n, 5)
+	}
+
+	if tb.logs[0] != "line" {
+		t.Errorf("log == %q, want %q", tb.logs[0], "line")
+	}
+
+	if tb.helpers == 0 {
+		t.Error("writer not marked as helper")
+	}
+}
@@ -0,0 +1,29 @@
+package logtest
+
+import (
+	"sync"
+	"testing"
+
+	logger "github.com/savsgio/go-logger/v4"
+)
+
+// LoggedEntry is an entry recorded by the recorder.
+type LoggedEntry struct {
+	logger.Entry
+
+	// Context contains the logger fields followed by the entry fields.
+	Context []logger.Field
+}
+
+// LoggedEntries is a list of recorded entries.
+type LoggedEntries []LoggedEntry
+
+// Recorder is a hook which records the logged entries in memory.
+type Recorder struct {
+	mu      sync.RWMutex
+	entries LoggedEntries
+}
+
+type tbWriter struct {
+	tb testing.TB
+}
@@ -0,0 +1,52 @@
+package logger
+
+// newPanicError returns a new panic error from the given entry.
+func newPanicError(e Entry) *PanicError {
+	perr := &PanicError{
+		Message: e.Message,
+		Level:   e.Level,
+		Fields:  make([]Field, 0, len(e.Config.Fields)+len(e.Fields)),
+		Caller:  e.Caller,
+	}
+
+	perr.Fields = append(perr.Fields, e.Config.Fields...)
+	perr.Fields = append(perr.Fields, e.Fields...)
+
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			perr.Err = err
+
+			break
+		}
+	}
+
+	return perr
+}
+
+// Error returns the panic message.
+func (e *PanicError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error of the message args, if any.
+func (e *PanicError) Unwrap() error {
+	return e.Err
+}
+
+// panicValue returns the value of the panic raised with the given panic error.
+func (l *Logger) panicValue(perr *PanicError) interface{} {
+	l.mu.RLock()
+	mode := l.panicMode
+	l.mu.RUnlock()
+
+	switch mode {
+	case PanicModeMessage:
+		return perr.Message
+	case PanicModeLogger:
+		return l
+	case PanicModeError:
+		fallthrough
+	default:
+		return perr
+	}
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_newPanicError(t *testing.T) {
+	errCause := errors.New("cause")
+
+	e := Entry{
+		Config:  Config{Fields: []Field{String("service", "api")}},
+		Level:   PANIC,
+		Message: "failed: cause",
+		Args:    []interface{}{"failed:", errCause},
+		Fields:  []Field{Int("status", 500)},
+	}
+	e.Caller.Function = "main.main"
+
+	perr := newPanicError(e)
+
+	if perr.Message != e.Message {
+		t.Errorf("Message == %s, want %s", perr.Message, e.Message)
+	}
+
+	if perr.Level != e.Level {
+		t.Errorf("Level == %s, want %s", perr.Level, e.Level)
+	}
+
+	wantFields := []Field{String("service", "api"), Int("status", 500)}
+	if !reflect.DeepEqual(perr.Fields, wantFields) {
+		t.Errorf("Fields == %v, want %v", perr.Fields, wantFields)
+	}
+
+	if perr.Caller != e.Caller {
+		t.Errorf("Caller == %v, want %v", perr.Caller, e.Caller)
+	}
+
+	if perr.Error() != e.Message {
+		t.Errorf("Error() == %s, want %s", perr.Error(), e.Message)
+	}
+
+	if !errors.Is(perr, errCause) {
+		t.Errorf("errors.Is(%v, %v) == false, want true", perr, errCause)
+	}
+}
+
+func TestLogger_Panic_disabled(t *testing.T) {
+	l := New(LevelExact(INFO), io.Discard, String("service", "api"))
+
+	defer func() {
+		perr, ok := recover().(*PanicError)
+		if !ok {
+			t.Fatal("the panic value is not a *PanicError")
+		}
+
+		if perr.Message != "failed 1" {
+			t.Errorf("Message == %s, want %s", perr.Message, "failed 1")
+		}
+
+		if _, file := filepath.Split(perr.Caller.File); file != "panic_test.go" {
+			t.Errorf("caller file == %s, want %s", file, "panic_test.go")
+		}
+
+		if len(perr.Fields) != 1 {
+			t.Errorf("fields == %v, want 1 field", perr.Fields)
+		}
+	}()
+
+	l.Panicf("failed %d", 1)
+}
+
+func TestLogger_Panic_redacted(t *testing.T) {
+	l := New(INFO, io.Discard, String("password", "secret"))
+	l.SetRedactor(newTestRedactor())
+	l.AddProcessor(ProcessorFunc(func(e *Entry) bool {
+		e.Fields = append(e.Fields, Lazy("api_token", func() interface{} { return "abc" }))
+
+		return true
+	}))
+
+	defer func() {
+		perr, ok := recover().(*PanicError)
+		if !ok {
+			t.Fatal("the panic value is not a *PanicError")
+		}
+
+		if want := "card " + defaultRedactorReplacement; perr.Message != want {
+			t.Errorf("Message == %s, want %s", perr.Message, want)
+		}
+
+		wantFields := map[string]interface{}{
+			"password": defaultRedactorReplacement, "api_token": defaultRedactorReplacement,
+		}
+
+		fields := make(map[string]interface{})
+		for _, field := range perr.Fields {
+			fields[field.Key] = field.Interface()
+		}
+
+		if !reflect.DeepEqual(fields, wantFields) {
+			t.Errorf("Fields == %v, want %v", fields, wantFields)
+		}
+	}()
+
+	l.Panicf("card 1234-5678-9012-3456")
+}
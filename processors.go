@@ -0,0 +1,27 @@
+package logger
+
+// Process calls fn(e).
+func (fn ProcessorFunc) Process(e *Entry) bool {
+	return fn(e)
+}
+
+// processEntry runs the processors, and returns false if any of them drops the entry.
+//
+// The entries emitted while processing are written by the given logger, which must be locked.
+func processEntry(processors []Processor, e *Entry, l *Logger) bool {
+	for _, p := range processors {
+		if ep, ok := p.(EmitProcessor); ok {
+			if !ep.ProcessEmit(e, l.writeEntry) {
+				return false
+			}
+
+			continue
+		}
+
+		if !p.Process(e) {
+			return false
+		}
+	}
+
+	return true
+}
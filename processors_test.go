@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"testing"
+)
+
+func TestProcessorFunc_Process(t *testing.T) {
+	called := false
+
+	fn := ProcessorFunc(func(e *Entry) bool {
+		called = true
+		e.Message = "processed"
+
+		return false
+	})
+
+	e := Entry{Message: "hello"}
+
+	if fn.Process(&e) {
+		t.Error("unexpected true result")
+	}
+
+	if !called {
+		t.Error("processor func not called")
+	}
+
+	if e.Message != "processed" {
+		t.Errorf("message == %s, want %s", e.Message, "processed")
+	}
+}
+
+func Test_processEntry(t *testing.T) { // nolint:funlen
+	calls := make([]string, 0)
+
+	newProcessor := func(name string, result bool) Processor {
+		return ProcessorFunc(func(e *Entry) bool {
+			calls = append(calls, name)
+			e.Fields = append(e.Fields, Field{Key: name, Value: result})
+
+			return result
+		})
+	}
+
+	type args struct {
+		processors []Processor
+	}
+
+	type want struct {
+		result bool
+		calls  []string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "empty",
+			args: args{},
+			want: want{
+				result: true,
+				calls:  []string{},
+			},
+		},
+		{
+			name: "all",
+			args: args{
+				processors: []Processor{newProcessor("p1", true), newProcessor("p2", true)},
+			},
+			want: want{
+				result: true,
+				calls:  []string{"p1", "p2"},
+			},
+		},
+		{
+			name: "dropped",
+			args: args{
+				processors: []Processor{
+					newProcessor("p1", true), newProcessor("p2", false), newProcessor("p3", true),
+				},
+			},
+			want: want{
+				result: false,
+				calls:  []string{"p1", "p2"},
+			},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			calls = calls[:0]
+
+			e := Entry{}
+
+			if result := processEntry(test.args.processors, &e, nil); result != test.want.result {
+				t.Errorf("result == %t, want %t", result, test.want.result)
+			}
+
+			if len(calls) != len(test.want.calls) {
+				t.Fatalf("calls == %v, want %v", calls, test.want.calls)
+			}
+
+			for i := range calls {
+				if calls[i] != test.want.calls[i] {
+					t.Errorf("calls == %v, want %v", calls, test.want.calls)
+				}
+			}
+
+			if len(e.Fields) != len(test.want.calls) {
+				t.Errorf("fields == %d, want %d", len(e.Fields), len(test.want.calls))
+			}
+		})
+	}
+}
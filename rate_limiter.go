@@ -0,0 +1,215 @@
+package logger
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// NewRateLimiter creates a new rate limiter processor.
+//
+// NOTE: Add it to the logger with AddProcessor, so the summaries are emitted,
+// and call Close to emit the pending summaries and stop its ticker.
+func NewRateLimiter(cfg RateLimiterConfig) *RateLimiter {
+	if cfg.Rate <= 0 {
+		cfg.Rate = defaultRateLimiterRate
+	}
+
+	if cfg.Burst <= 0 {
+		cfg.Burst = int(math.Max(1, math.Ceil(cfg.Rate)))
+	}
+
+	if cfg.SummaryInterval <= 0 {
+		cfg.SummaryInterval = defaultRateLimiterSummaryInterval
+	}
+
+	if cfg.Clock == nil {
+		cfg.Clock = defaultClock
+	}
+
+	rl := &RateLimiter{
+		cfg:     cfg,
+		buckets: make(map[rateLimitKey]*rateLimitBucket),
+		ticker:  time.NewTicker(cfg.SummaryInterval),
+		done:    make(chan struct{}),
+	}
+
+	go rl.run()
+
+	return rl
+}
+
+func (rl *RateLimiter) run() {
+	for {
+		select {
+		case <-rl.ticker.C:
+			rl.Flush() // nolint:errcheck
+		case <-rl.done:
+			return
+		}
+	}
+}
+
+// Process drops the entry if its level, or its key value, has run out of tokens.
+func (rl *RateLimiter) Process(e *Entry) bool {
+	if rl.cfg.Filter != nil && !rl.cfg.Filter.Enabled(e.Level) {
+		return true
+	}
+
+	key := rateLimitKey{level: e.Level}
+
+	if rl.cfg.Key != "" {
+		field := findEntryField(e, rl.cfg.Key)
+		if field == nil {
+			return true
+		}
+
+		key = rateLimitKey{value: fieldValueString(*field)}
+	}
+
+	now := rl.cfg.Clock.Now()
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	b := rl.buckets[key]
+	if b == nil {
+		b = &rateLimitBucket{tokens: float64(rl.cfg.Burst), last: now}
+		rl.buckets[key] = b
+	}
+
+	b.refill(now, rl.cfg.Rate, rl.cfg.Burst)
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return true
+	}
+
+	b.suppressed++
+
+	if b.suppressed == 1 {
+		b.entry = copyEntry(*e)
+		b.entry.Args = nil
+	} else if e.Level.Severity() < b.entry.Level.Severity() {
+		b.entry.Level = e.Level
+	}
+
+	return false
+}
+
+// ProcessEmit is like Process, since the summaries are only emitted periodically.
+func (rl *RateLimiter) ProcessEmit(e *Entry, _ EmitFunc) bool {
+	return rl.Process(e)
+}
+
+// SetEmit sets the function to emit the summaries.
+func (rl *RateLimiter) SetEmit(emit EmitFunc) {
+	rl.mu.Lock()
+	rl.emit = emit
+	rl.mu.Unlock()
+}
+
+// Flush emits the summaries of the suppressed entries, if any, without waiting the summary interval,
+// and removes the idle buckets.
+func (rl *RateLimiter) Flush() error {
+	now := rl.cfg.Clock.Now()
+
+	rl.mu.Lock()
+
+	emit := rl.emit
+	summaries := make([]Entry, 0)
+
+	for key, b := range rl.buckets {
+		if b.suppressed > 0 {
+			summaries = append(summaries, rl.summary(now, key, b))
+
+			b.suppressed = 0
+			b.entry = Entry{}
+
+			continue
+		}
+
+		if b.refill(now, rl.cfg.Rate, rl.cfg.Burst); b.tokens >= float64(rl.cfg.Burst) {
+			delete(rl.buckets, key)
+		}
+	}
+
+	rl.mu.Unlock()
+
+	if emit != nil {
+		for i := range summaries {
+			emit(summaries[i])
+		}
+	}
+
+	return nil
+}
+
+// Close stops the ticker, and emits the pending summaries.
+func (rl *RateLimiter) Close() error {
+	rl.closeOnce.Do(func() {
+		rl.ticker.Stop()
+		close(rl.done)
+	})
+
+	return rl.Flush()
+}
+
+// summary returns the summary entry of the bucket, from its first suppressed entry,
+// with the most severe suppressed level.
+func (rl *RateLimiter) summary(now time.Time, key rateLimitKey, b *rateLimitBucket) Entry {
+	e := b.entry
+	e.Message = fmt.Sprintf(rateLimiterSummaryMessage, b.suppressed)
+	e.RawMessage = rateLimiterSummaryMessage
+	e.Args = []interface{}{b.suppressed}
+	e.Fields = []Field{Int(rateLimiterSummaryFieldKey, b.suppressed)}
+
+	if rl.cfg.Key != "" && findEntryField(&e, rl.cfg.Key) == nil {
+		e.Fields = append(e.Fields, String(rl.cfg.Key, key.value))
+	}
+
+	if !e.Time.IsZero() {
+		e.Time = now.In(e.Time.Location())
+	}
+
+	return e
+}
+
+// refill adds the tokens for the elapsed time, up to the burst.
+func (b *rateLimitBucket) refill(now time.Time, rate float64, burst int) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(burst), b.tokens+elapsed.Seconds()*rate)
+	}
+
+	b.last = now
+}
+
+// findEntryField returns the field with the given key of the entry,
+// looking first at the entry fields and then at the logger ones.
+func findEntryField(e *Entry, key string) *Field {
+	for i := len(e.Fields) - 1; i >= 0; i-- {
+		if e.Fields[i].Key == key && e.Fields[i].typ != fieldTypeNamespace {
+			return &e.Fields[i]
+		}
+	}
+
+	for i := len(e.Config.Fields) - 1; i >= 0; i-- {
+		if e.Config.Fields[i].Key == key && e.Config.Fields[i].typ != fieldTypeNamespace {
+			return &e.Config.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+// fieldValueString returns the value of the field as string.
+//
+// NOTE: The bytes values are copied, since they could be modified after.
+func fieldValueString(f Field) string {
+	if f.typ == fieldTypeString {
+		return f.str
+	}
+
+	return fmt.Sprint(f.resolve().Interface())
+}
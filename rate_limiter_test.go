@@ -0,0 +1,316 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func Test_NewRateLimiter(t *testing.T) {
+	type args struct {
+		cfg RateLimiterConfig
+	}
+
+	type want struct {
+		rate  float64
+		burst int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Default",
+			args: args{},
+			want: want{rate: defaultRateLimiterRate, burst: defaultRateLimiterRate},
+		},
+		{
+			name: "Rate",
+			args: args{cfg: RateLimiterConfig{Rate: 2.5}},
+			want: want{rate: 2.5, burst: 3},
+		},
+		{
+			name: "LowRate",
+			args: args{cfg: RateLimiterConfig{Rate: 0.1}},
+			want: want{rate: 0.1, burst: 1},
+		},
+		{
+			name: "Burst",
+			args: args{cfg: RateLimiterConfig{Rate: 10, Burst: 50}},
+			want: want{rate: 10, burst: 50},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			rl := NewRateLimiter(test.args.cfg)
+			defer rl.Close()
+
+			if rl.cfg.Rate != test.want.rate {
+				t.Errorf("Rate == %f, want %f", rl.cfg.Rate, test.want.rate)
+			}
+
+			if rl.cfg.Burst != test.want.burst {
+				t.Errorf("Burst == %d, want %d", rl.cfg.Burst, test.want.burst)
+			}
+
+			if rl.cfg.SummaryInterval != defaultRateLimiterSummaryInterval {
+				t.Errorf("SummaryInterval == %s, want %s", rl.cfg.SummaryInterval, defaultRateLimiterSummaryInterval)
+			}
+
+			if rl.cfg.Clock == nil {
+				t.Error("Clock is nil")
+			}
+		})
+	}
+}
+
+func TestRateLimiter_Level(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+
+	rl := NewRateLimiter(RateLimiterConfig{Rate: 2, Filter: ERROR, Clock: clock})
+	defer rl.Close()
+
+	output := new(bytes.Buffer)
+
+	l := New(INFO, output)
+	l.SetFlags(0)
+	l.AddProcessor(rl)
+
+	for i := 0; i < 5; i++ {
+		l.Errorf("error %d", i)
+		l.Infof("info %d", i)
+	}
+
+	clock.Add(500 * time.Millisecond)
+
+	l.Error("error 5")
+	l.Error("error 6")
+	l.Warning("warning")
+
+	if err := l.Sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "ERROR - error 0\nINFO - info 0\nERROR - error 1\nINFO - info 1\n" +
+		"INFO - info 2\nINFO - info 3\nINFO - info 4\n" +
+		"ERROR - error 5\n" +
+		"WARNING - warning\n" +
+		"ERROR - suppressed=4 - 4 entries suppressed by the rate limit\n"
+
+	if output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+
+	// The summary is emitted once.
+	output.Reset()
+
+	if err := l.Sync(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.Len() != 0 {
+		t.Errorf("output == %q, want empty", output.String())
+	}
+}
+
+func TestRateLimiter_Key(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+
+	rl := NewRateLimiter(RateLimiterConfig{Rate: 1, Key: "client_ip", Clock: clock})
+	defer rl.Close()
+
+	output := new(bytes.Buffer)
+
+	l := New(TRACE, output)
+	l.SetFlags(0)
+	l.AddProcessor(rl)
+
+	l1 := l.WithFields(String("client_ip", "10.0.0.1"))
+	l2 := l.WithFields(String("client_ip", "10.0.0.2"))
+
+	l1.Info("request 1")
+	l1.Debug("request 2")
+	l1.Error("request 3")
+	l2.Info("request 4")
+	l.Info("request 5")
+	l.Info("request 6")
+
+	if err := rl.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "INFO - client_ip=10.0.0.1 - request 1\n" +
+		"INFO - client_ip=10.0.0.2 - request 4\n" +
+		"INFO - request 5\nINFO - request 6\n" +
+		"ERROR - client_ip=10.0.0.1 - suppressed=2 - 2 entries suppressed by the rate limit\n"
+
+	if output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestRateLimiter_Flush(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+
+	rl := NewRateLimiter(RateLimiterConfig{Rate: 1, Key: "id", Clock: clock})
+	defer rl.Close()
+
+	for i := 0; i < 3; i++ {
+		e := Entry{Level: INFO, Fields: []Field{Int("id", i)}}
+		rl.Process(&e)
+	}
+
+	if len(rl.buckets) != 3 {
+		t.Fatalf("buckets == %d, want %d", len(rl.buckets), 3)
+	}
+
+	// Not refilled yet.
+	rl.Flush() // nolint:errcheck
+
+	if len(rl.buckets) != 3 {
+		t.Errorf("buckets == %d, want %d", len(rl.buckets), 3)
+	}
+
+	clock.Add(time.Second)
+
+	rl.Flush() // nolint:errcheck
+
+	if len(rl.buckets) != 0 {
+		t.Errorf("buckets == %d, want %d", len(rl.buckets), 0)
+	}
+}
+
+func TestRateLimiter_SummaryInterval(t *testing.T) {
+	output := new(syncBuffer)
+
+	rl := NewRateLimiter(RateLimiterConfig{Rate: 1, SummaryInterval: 10 * time.Millisecond})
+	defer rl.Close()
+
+	l := New(INFO, output)
+	l.SetFlags(0)
+	l.AddProcessor(rl)
+
+	l.Warning("slow")
+	l.Warning("slow")
+
+	want := "WARNING - slow\nWARNING - suppressed=1 - 1 entries suppressed by the rate limit\n"
+
+	for i := 0; i < 100 && output.String() != want; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func Test_findEntryField(t *testing.T) {
+	e := Entry{
+		Config: Config{Fields: []Field{String("foo", "config"), String("bar", "config")}},
+		Fields: []Field{String("foo", "entry"), Namespace("baz")},
+	}
+
+	type args struct {
+		key string
+	}
+
+	type want struct {
+		value string
+		found bool
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Entry",
+			args: args{key: "foo"},
+			want: want{value: "entry", found: true},
+		},
+		{
+			name: "Config",
+			args: args{key: "bar"},
+			want: want{value: "config", found: true},
+		},
+		{
+			name: "Namespace",
+			args: args{key: "baz"},
+			want: want{found: false},
+		},
+		{
+			name: "NotFound",
+			args: args{key: "qux"},
+			want: want{found: false},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			field := findEntryField(&e, test.args.key)
+
+			if (field != nil) != test.want.found {
+				t.Fatalf("found == %t, want %t", field != nil, test.want.found)
+			}
+
+			if field != nil && field.str != test.want.value {
+				t.Errorf("value == %s, want %s", field.str, test.want.value)
+			}
+		})
+	}
+}
+
+func Test_fieldValueString(t *testing.T) {
+	type args struct {
+		field Field
+	}
+
+	type want struct {
+		value string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "String",
+			args: args{field: String("ip", "10.0.0.1")},
+			want: want{value: "10.0.0.1"},
+		},
+		{
+			name: "Bytes",
+			args: args{field: Bytes("ip", []byte("10.0.0.1"))},
+			want: want{value: "10.0.0.1"},
+		},
+		{
+			name: "Int",
+			args: args{field: Int("id", 10)},
+			want: want{value: "10"},
+		},
+		{
+			name: "Lazy",
+			args: args{field: Lazy("id", func() interface{} { return 11 })},
+			want: want{value: "11"},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			if value := fieldValueString(test.args.field); value != test.want.value {
+				t.Errorf("value == %s, want %s", value, test.want.value)
+			}
+		})
+	}
+}
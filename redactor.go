@@ -0,0 +1,354 @@
+package logger
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"time"
+)
+
+// NewRedactor creates a new redactor.
+func NewRedactor(cfg RedactorConfig) *Redactor {
+	if cfg.Replacement == "" {
+		cfg.Replacement = defaultRedactorReplacement
+	}
+
+	r := new(Redactor)
+	r.cfg = cfg
+	r.keys = make([]string, len(cfg.Keys))
+
+	for i, key := range cfg.Keys {
+		r.keys[i] = strings.ToLower(key)
+	}
+
+	return r
+}
+
+func (r *Redactor) matchKey(key string) bool {
+	if len(r.keys) == 0 {
+		return false
+	}
+
+	key = strings.ToLower(key)
+
+	for _, pattern := range r.keys {
+		if pattern == key {
+			return true
+		}
+
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Redactor) redactString(value string) string {
+	for _, re := range r.cfg.Patterns {
+		if re.MatchString(value) {
+			value = re.ReplaceAllLiteralString(value, r.cfg.Replacement)
+		}
+	}
+
+	return value
+}
+
+func (r *Redactor) redactValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case Redactable:
+		return v.Redacted(), true
+	case string:
+		redactedValue := r.redactString(v)
+
+		return redactedValue, redactedValue != v
+	case nil:
+		return v, false
+	}
+
+	if len(r.cfg.Patterns) == 0 {
+		return value, false
+	}
+
+	strValue := fmt.Sprint(value)
+
+	if redactedValue := r.redactString(strValue); redactedValue != strValue {
+		return redactedValue, true
+	}
+
+	return value, false
+}
+
+func (r *Redactor) redactField(field Field) (Field, bool) {
+	if field.typ == fieldTypeNamespace {
+		return field, false
+	}
+
+	if r.matchKey(field.Key) {
+		return Any(field.Key, r.cfg.Replacement), true
+	}
+
+	return r.redactFieldValue(field)
+}
+
+// redactFieldValue redacts the value of the field, without matching its key,
+// like the values of the arrays.
+func (r *Redactor) redactFieldValue(field Field) (Field, bool) {
+	switch field.marshalerType() {
+	case fieldTypeString, fieldTypeBytes:
+		if value := r.redactString(field.str); value != field.str {
+			return String(field.Key, value), true
+		}
+	case fieldTypeObject, fieldTypeArray:
+		return r.redactMarshaler(field)
+	case fieldTypeAny, fieldTypeStringer:
+		if value, redacted := r.redactValue(field.Value); redacted {
+			return Any(field.Key, value), true
+		}
+	case fieldTypeInt64, fieldTypeFloat64, fieldTypeBool, fieldTypeDuration, fieldTypeTime, fieldTypeNamespace:
+		if len(r.cfg.Patterns) == 0 {
+			break
+		}
+
+		if value, redacted := r.redactValue(field.Interface()); redacted {
+			return Any(field.Key, value), true
+		}
+	}
+
+	return field, false
+}
+
+// redactMarshaler returns the field with the marshaler value wrapped,
+// so its nested keys and values are redacted when encoded.
+//
+// NOTE: The Redactable values are redacted by themselves, and the nil values are kept.
+func (r *Redactor) redactMarshaler(field Field) (Field, bool) {
+	if value, ok := field.Value.(Redactable); ok {
+		return Any(field.Key, value.Redacted()), true
+	}
+
+	if isNilValue(field.Value) || (len(r.keys) == 0 && len(r.cfg.Patterns) == 0) {
+		return field, false
+	}
+
+	if field.marshalerType() == fieldTypeObject {
+		field.Value = &redactedObject{r: r, value: field.Value.(ObjectMarshaler)} // nolint:forcetypeassert
+	} else {
+		field.Value = &redactedArray{r: r, value: field.Value.(ArrayMarshaler)} // nolint:forcetypeassert
+	}
+
+	return field, true
+}
+
+// redactFields returns the redacted fields, copying them only if needed,
+// since the given slice could be shared.
+func (r *Redactor) redactFields(fields []Field) []Field {
+	var result []Field
+
+	for i := range fields {
+		field, redacted := r.redactField(fields[i])
+
+		if result == nil {
+			if !redacted {
+				continue
+			}
+
+			result = make([]Field, len(fields))
+			copy(result, fields)
+		}
+
+		result[i] = field
+	}
+
+	if result == nil {
+		return fields
+	}
+
+	return result
+}
+
+// redactArgs returns the redacted args, copying them only if needed,
+// since the given slice could be owned by the caller.
+func (r *Redactor) redactArgs(args []interface{}) []interface{} {
+	var result []interface{}
+
+	for i := range args {
+		value, redacted := r.redactValue(args[i])
+
+		if result == nil {
+			if !redacted {
+				continue
+			}
+
+			result = make([]interface{}, len(args))
+			copy(result, args)
+		}
+
+		result[i] = value
+	}
+
+	if result == nil {
+		return args
+	}
+
+	return result
+}
+
+func (r *Redactor) redactEntry(e *Entry) {
+	e.Message = r.redactString(e.Message)
+	e.RawMessage = r.redactString(e.RawMessage)
+	e.Fields = r.redactFields(e.Fields)
+}
+
+// MarshalLogObject marshals the wrapped object with a redactor encoder.
+func (o *redactedObject) MarshalLogObject(enc ObjectEncoder) error {
+	return o.value.MarshalLogObject(&redactorObjectEncoder{r: o.r, enc: enc})
+}
+
+// MarshalLogArray marshals the wrapped array with a redactor encoder.
+func (a *redactedArray) MarshalLogArray(enc ArrayEncoder) error {
+	return a.value.MarshalLogArray(&redactorArrayEncoder{r: a.r, enc: enc})
+}
+
+// addField redacts the field, and adds it to the wrapped encoder by its type.
+func (oe *redactorObjectEncoder) addField(field Field) {
+	field, _ = oe.r.redactField(field)
+
+	switch field.marshalerType() {
+	case fieldTypeString, fieldTypeBytes:
+		oe.enc.AddString(field.Key, field.str)
+	case fieldTypeInt64:
+		oe.enc.AddInt64(field.Key, field.integer)
+	case fieldTypeFloat64:
+		oe.enc.AddFloat64(field.Key, math.Float64frombits(uint64(field.integer)))
+	case fieldTypeBool:
+		oe.enc.AddBool(field.Key, field.integer == 1)
+	case fieldTypeDuration:
+		oe.enc.AddDuration(field.Key, time.Duration(field.integer))
+	case fieldTypeTime:
+		oe.enc.AddTime(field.Key, field.time())
+	case fieldTypeObject:
+		oe.enc.AddObject(field.Key, field.Value.(ObjectMarshaler)) // nolint:forcetypeassert
+	case fieldTypeArray:
+		oe.enc.AddArray(field.Key, field.Value.(ArrayMarshaler)) // nolint:forcetypeassert
+	case fieldTypeAny, fieldTypeStringer, fieldTypeNamespace:
+		oe.enc.AddAny(field.Key, field.Value)
+	}
+}
+
+// AddString adds a string value.
+func (oe *redactorObjectEncoder) AddString(key, value string) {
+	oe.addField(stringField(key, value))
+}
+
+// AddInt64 adds an int64 value.
+func (oe *redactorObjectEncoder) AddInt64(key string, value int64) {
+	oe.addField(int64Field(key, value))
+}
+
+// AddFloat64 adds a float64 value.
+func (oe *redactorObjectEncoder) AddFloat64(key string, value float64) {
+	oe.addField(float64Field(key, value))
+}
+
+// AddBool adds a bool value.
+func (oe *redactorObjectEncoder) AddBool(key string, value bool) {
+	oe.addField(boolField(key, value))
+}
+
+// AddDuration adds a duration value.
+func (oe *redactorObjectEncoder) AddDuration(key string, value time.Duration) {
+	oe.addField(durationField(key, value))
+}
+
+// AddTime adds a time value.
+func (oe *redactorObjectEncoder) AddTime(key string, value time.Time) {
+	oe.addField(timeField(key, value))
+}
+
+// AddObject adds an object value.
+func (oe *redactorObjectEncoder) AddObject(key string, value ObjectMarshaler) {
+	oe.addField(Object(key, value))
+}
+
+// AddArray adds an array value.
+func (oe *redactorObjectEncoder) AddArray(key string, value ArrayMarshaler) {
+	oe.addField(Array(key, value))
+}
+
+// AddAny adds a value of any type.
+func (oe *redactorObjectEncoder) AddAny(key string, value interface{}) {
+	oe.addField(Any(key, value))
+}
+
+// appendField redacts the value of the field, and appends it to the wrapped encoder by its type.
+func (ae *redactorArrayEncoder) appendField(field Field) {
+	field, _ = ae.r.redactFieldValue(field)
+
+	switch field.marshalerType() {
+	case fieldTypeString, fieldTypeBytes:
+		ae.enc.AppendString(field.str)
+	case fieldTypeInt64:
+		ae.enc.AppendInt64(field.integer)
+	case fieldTypeFloat64:
+		ae.enc.AppendFloat64(math.Float64frombits(uint64(field.integer)))
+	case fieldTypeBool:
+		ae.enc.AppendBool(field.integer == 1)
+	case fieldTypeDuration:
+		ae.enc.AppendDuration(time.Duration(field.integer))
+	case fieldTypeTime:
+		ae.enc.AppendTime(field.time())
+	case fieldTypeObject:
+		ae.enc.AppendObject(field.Value.(ObjectMarshaler)) // nolint:forcetypeassert
+	case fieldTypeArray:
+		ae.enc.AppendArray(field.Value.(ArrayMarshaler)) // nolint:forcetypeassert
+	case fieldTypeAny, fieldTypeStringer, fieldTypeNamespace:
+		ae.enc.AppendAny(field.Value)
+	}
+}
+
+// AppendString appends a string value.
+func (ae *redactorArrayEncoder) AppendString(value string) {
+	ae.appendField(stringField("", value))
+}
+
+// AppendInt64 appends an int64 value.
+func (ae *redactorArrayEncoder) AppendInt64(value int64) {
+	ae.appendField(int64Field("", value))
+}
+
+// AppendFloat64 appends a float64 value.
+func (ae *redactorArrayEncoder) AppendFloat64(value float64) {
+	ae.appendField(float64Field("", value))
+}
+
+// AppendBool appends a bool value.
+func (ae *redactorArrayEncoder) AppendBool(value bool) {
+	ae.appendField(boolField("", value))
+}
+
+// AppendDuration appends a duration value.
+func (ae *redactorArrayEncoder) AppendDuration(value time.Duration) {
+	ae.appendField(durationField("", value))
+}
+
+// AppendTime appends a time value.
+func (ae *redactorArrayEncoder) AppendTime(value time.Time) {
+	ae.appendField(timeField("", value))
+}
+
+// AppendObject appends an object value.
+func (ae *redactorArrayEncoder) AppendObject(value ObjectMarshaler) {
+	ae.appendField(Object("", value))
+}
+
+// AppendArray appends an array value.
+func (ae *redactorArrayEncoder) AppendArray(value ArrayMarshaler) {
+	ae.appendField(Array("", value))
+}
+
+// AppendAny appends a value of any type.
+func (ae *redactorArrayEncoder) AppendAny(value interface{}) {
+	ae.appendField(Any("", value))
+}
@@ -0,0 +1,325 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type testRedactable struct {
+	secret string
+}
+
+func (v testRedactable) Redacted() interface{} {
+	return strings.Repeat("*", len(v.secret))
+}
+
+type testCredentials struct {
+	password string
+	cards    testArray
+}
+
+func (c testCredentials) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("user", "savsgio")
+	enc.AddString("password", c.password)
+
+	if c.cards != nil {
+		enc.AddArray("cards", c.cards)
+	}
+
+	return nil
+}
+
+func newTestRedactor() *Redactor {
+	return NewRedactor(RedactorConfig{
+		Keys:     []string{"Password", "*_token"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`\b\d{4}-\d{4}-\d{4}-\d{4}\b`)},
+	})
+}
+
+func Test_NewRedactor(t *testing.T) {
+	r := NewRedactor(RedactorConfig{})
+
+	if r.cfg.Replacement != defaultRedactorReplacement {
+		t.Errorf("replacement == %s, want %s", r.cfg.Replacement, defaultRedactorReplacement)
+	}
+
+	r = NewRedactor(RedactorConfig{Keys: []string{"PassWord"}, Replacement: "***"})
+
+	if r.cfg.Replacement != "***" {
+		t.Errorf("replacement == %s, want %s", r.cfg.Replacement, "***")
+	}
+
+	if wantKeys := []string{"password"}; !reflect.DeepEqual(r.keys, wantKeys) {
+		t.Errorf("keys == %v, want %v", r.keys, wantKeys)
+	}
+}
+
+func TestRedactor_matchKey(t *testing.T) {
+	r := newTestRedactor()
+
+	tests := map[string]bool{
+		"password":     true,
+		"PASSWORD":     true,
+		"access_token": true,
+		"Access_Token": true,
+		"token":        false,
+		"user":         false,
+	}
+
+	for key, want := range tests {
+		if result := r.matchKey(key); result != want {
+			t.Errorf("key %s == %t, want %t", key, result, want)
+		}
+	}
+}
+
+func TestRedactor_redactValue(t *testing.T) { // nolint:funlen
+	r := newTestRedactor()
+
+	type want struct {
+		value    interface{}
+		redacted bool
+	}
+
+	tests := []struct {
+		value interface{}
+		want  want
+	}{
+		{
+			value: "card 1234-5678-1234-5678 used",
+			want:  want{value: "card [REDACTED] used", redacted: true},
+		},
+		{
+			value: "hello world",
+			want:  want{value: "hello world", redacted: false},
+		},
+		{
+			value: testRedactable{secret: "1234"},
+			want:  want{value: "****", redacted: true},
+		},
+		{
+			value: []string{"1234-5678-1234-5678"},
+			want:  want{value: "[[REDACTED]]", redacted: true},
+		},
+		{
+			value: []int{1, 2, 3},
+			want:  want{value: []int{1, 2, 3}, redacted: false},
+		},
+		{
+			value: nil,
+			want:  want{value: nil, redacted: false},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run("", func(t *testing.T) {
+			value, redacted := r.redactValue(test.value)
+
+			if !reflect.DeepEqual(value, test.want.value) {
+				t.Errorf("value == %v, want %v", value, test.want.value)
+			}
+
+			if redacted != test.want.redacted {
+				t.Errorf("redacted == %t, want %t", redacted, test.want.redacted)
+			}
+		})
+	}
+}
+
+func TestRedactor_redactFields(t *testing.T) {
+	r := newTestRedactor()
+
+	fields := []Field{{Key: "user", Value: "savsgio"}, {Key: "password", Value: "1234"}, {Key: "API_TOKEN", Value: "abcd"}}
+	result := r.redactFields(fields)
+
+	wantResult := []Field{{Key: "user", Value: "savsgio"}, {Key: "password", Value: "[REDACTED]"}, {Key: "API_TOKEN", Value: "[REDACTED]"}}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("fields == %v, want %v", result, wantResult)
+	}
+
+	if fields[1].Value != "1234" {
+		t.Error("the given fields have been modified")
+	}
+
+	fields = []Field{{Key: "user", Value: "savsgio"}}
+
+	if result := r.redactFields(fields); &result[0] != &fields[0] {
+		t.Error("the fields have been copied without changes")
+	}
+}
+
+func TestRedactor_redactArgs(t *testing.T) {
+	r := newTestRedactor()
+
+	args := []interface{}{"hello", testRedactable{secret: "123"}, []int{1}}
+	result := r.redactArgs(args)
+
+	wantResult := []interface{}{"hello", "***", []int{1}}
+	if !reflect.DeepEqual(result, wantResult) {
+		t.Errorf("args == %v, want %v", result, wantResult)
+	}
+
+	if _, ok := args[1].(testRedactable); !ok {
+		t.Error("the given args have been modified")
+	}
+
+	args = []interface{}{"hello"}
+
+	if result := r.redactArgs(args); &result[0] != &args[0] {
+		t.Error("the args have been copied without changes")
+	}
+}
+
+func TestRedactor_redactEntry(t *testing.T) {
+	r := newTestRedactor()
+
+	e := Entry{
+		Message:    "pay with 1234-5678-1234-5678",
+		RawMessage: "pay with 1234-5678-1234-5678",
+		Fields:     []Field{{Key: "session_token", Value: "abc"}},
+	}
+
+	r.redactEntry(&e)
+
+	if want := "pay with [REDACTED]"; e.Message != want {
+		t.Errorf("message == %s, want %s", e.Message, want)
+	}
+
+	if want := "pay with [REDACTED]"; e.RawMessage != want {
+		t.Errorf("raw message == %s, want %s", e.RawMessage, want)
+	}
+
+	if want := "[REDACTED]"; e.Fields[0].Value != want {
+		t.Errorf("field value == %v, want %s", e.Fields[0].Value, want)
+	}
+}
+
+func TestRedactor_Logger(t *testing.T) { // nolint:funlen
+	encoders := map[string]Encoder{
+		"text": NewEncoderText(EncoderTextConfig{}),
+		"json": NewEncoderJSON(EncoderJSONConfig{}),
+	}
+
+	for name, enc := range encoders {
+		enc := enc
+
+		t.Run(name, func(t *testing.T) {
+			output := new(bytes.Buffer)
+
+			l := New(INFO, output, Field{Key: "password", Value: "secret"})
+			l.SetFlags(0)
+			l.SetEncoder(enc)
+			l.SetRedactor(newTestRedactor())
+			l.SetFields(Field{Key: "refresh_token", Value: "secret"})
+			l.AddProcessor(ProcessorFunc(func(e *Entry) bool {
+				e.Fields = append(e.Fields, Field{Key: "auth_token", Value: "secret"})
+
+				return true
+			}))
+
+			var hookEntry Entry
+
+			err := l.AddHook(&testHook{
+				levels: []Level{INFO},
+				fireFunc: func(e Entry) error {
+					hookEntry = e
+
+					return nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			l.Infof("card %s of %v", "1234-5678-1234-5678", testRedactable{secret: "savsgio"})
+
+			if result := output.String(); strings.Contains(result, "secret") || strings.Contains(result, "1234") {
+				t.Errorf("output not redacted: %s", result)
+			}
+
+			for _, fields := range [][]Field{hookEntry.Config.Fields, hookEntry.Fields} {
+				for _, field := range fields {
+					if field.Value != defaultRedactorReplacement {
+						t.Errorf("hook field %s == %v, want %s", field.Key, field.Value, defaultRedactorReplacement)
+					}
+				}
+			}
+
+			if want := "card [REDACTED] of *******"; hookEntry.Message != want {
+				t.Errorf("hook message == %s, want %s", hookEntry.Message, want)
+			}
+		})
+	}
+}
+
+func TestRedactor_Logger_marshalers(t *testing.T) { // nolint:funlen
+	creds := testCredentials{
+		password: "secret",
+		cards:    testArray{"1234-5678-1234-5678", testCredentials{password: "secret"}},
+	}
+
+	redactors := map[string]*Redactor{
+		"keys":     NewRedactor(RedactorConfig{Keys: []string{"Password"}}),
+		"patterns": newTestRedactor(),
+	}
+
+	encoders := map[string]func() Encoder{
+		"text": func() Encoder { return NewEncoderText(EncoderTextConfig{}) },
+		"json": func() Encoder { return NewEncoderJSON(EncoderJSONConfig{}) },
+	}
+
+	fields := map[string]Field{
+		"Object": Object("creds", creds),
+		"Array":  Array("creds", testArray{creds}),
+		"Any":    Any("creds", creds),
+	}
+
+	for redactorName, r := range redactors {
+		for encoderName, newEncoder := range encoders {
+			for fieldName, field := range fields {
+				r, newEncoder, field := r, newEncoder, field
+				encoderName, redactorName := encoderName, redactorName
+
+				t.Run(redactorName+"/"+encoderName+"/"+fieldName, func(t *testing.T) {
+					output := new(bytes.Buffer)
+
+					l := New(INFO, output)
+					l.SetFlags(0)
+					l.SetEncoder(newEncoder())
+					l.SetRedactor(r)
+					l.LogFields(INFO, "hello", field)
+
+					result := output.String()
+
+					if strings.Contains(result, "secret") || !strings.Contains(result, "savsgio") {
+						t.Errorf("output not redacted: %s", result)
+					}
+
+					if redactorName == "patterns" && strings.Contains(result, "1234") {
+						t.Errorf("output not redacted: %s", result)
+					}
+
+					want := "password=[REDACTED]"
+
+					if encoderName == "json" {
+						want = `"password":"[REDACTED]"`
+
+						if !json.Valid([]byte(result)) {
+							t.Errorf("output is not a valid json: %s", result)
+						}
+					}
+
+					if !strings.Contains(result, want) {
+						t.Errorf("output == %s, want %s", result, want)
+					}
+				})
+			}
+		}
+	}
+}
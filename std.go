@@ -3,6 +3,7 @@ package logger
 import (
 	"io"
 	"os"
+	"time"
 )
 
 var std = newStd()
@@ -22,6 +23,30 @@ func WithFields(fields ...Field) *Logger {
 	return l
 }
 
+// WithGroup returns a copy of the standard logger whose subsequent fields are nested under the given name.
+func WithGroup(name string) *Logger {
+	l := std.WithGroup(name)
+	l.setCalldepth(calldepth)
+
+	return l
+}
+
+// WithCallerSkip returns a copy of the standard logger which skips n more frames when getting the caller.
+func WithCallerSkip(n int) *Logger {
+	l := std.WithCallerSkip(n)
+	l.setCalldepth(calldepth + n)
+
+	return l
+}
+
+// WithBacktrace returns a copy of the standard logger which records the disabled entries into the given backtrace.
+func WithBacktrace(b *Backtrace) *Logger {
+	l := std.WithBacktrace(b)
+	l.setCalldepth(calldepth)
+
+	return l
+}
+
 // SetFields sets the fields to the standard logger.
 func SetFields(fields ...Field) {
 	std.SetFields(fields...)
@@ -32,8 +57,13 @@ func SetFlags(flag Flag) {
 	std.SetFlags(flag)
 }
 
+// SetLocation sets the time zone to the standard logger.
+func SetLocation(loc *time.Location) {
+	std.SetLocation(loc)
+}
+
 // SetLevel sets the level to the standard logger.
-func SetLevel(level Level) {
+func SetLevel(level LevelFilter) {
 	std.SetLevel(level)
 }
 
@@ -42,11 +72,36 @@ func SetOutput(output io.Writer) {
 	std.SetOutput(output)
 }
 
+// SetClock sets the clock to the standard logger.
+func SetClock(clock Clock) {
+	std.SetClock(clock)
+}
+
+// SetPanicMode sets the panic mode to the standard logger.
+func SetPanicMode(mode PanicMode) {
+	std.SetPanicMode(mode)
+}
+
+// SetExitTimeout sets the exit timeout to the standard logger.
+func SetExitTimeout(timeout time.Duration) {
+	std.SetExitTimeout(timeout)
+}
+
+// SetBacktrace sets the backtrace to the standard logger.
+func SetBacktrace(b *Backtrace) {
+	std.SetBacktrace(b)
+}
+
 // SetEncoder sets the encoder to the standard logger.
 func SetEncoder(enc Encoder) {
 	std.SetEncoder(enc)
 }
 
+// SetRedactor sets the redactor to the standard logger.
+func SetRedactor(r *Redactor) {
+	std.SetRedactor(r)
+}
+
 // IsLevelEnabled checks if the given level is enabled on the standard logger.
 func IsLevelEnabled(level Level) bool {
 	return std.IsLevelEnabled(level)
@@ -57,6 +112,36 @@ func AddHook(h Hook) error {
 	return std.AddHook(h)
 }
 
+// AddProcessor registers the given processor to the standard logger.
+func AddProcessor(p Processor) {
+	std.AddProcessor(p)
+}
+
+// Log logs with the given level to the standard logger.
+func Log(level Level, msg ...interface{}) {
+	std.Log(level, msg...)
+}
+
+// Logf logs with the given level and format to the standard logger.
+func Logf(level Level, msg string, args ...interface{}) {
+	std.Logf(level, msg, args...)
+}
+
+// LogFields logs with the given level and fields to the standard logger.
+func LogFields(level Level, msg string, fields ...Field) {
+	std.LogFields(level, msg, fields...)
+}
+
+// Sync syncs the standard logger.
+func Sync() error {
+	return std.Sync()
+}
+
+// Close closes the standard logger.
+func Close() error {
+	return std.Close()
+}
+
 func Print(msg ...interface{}) {
 	std.Print(msg...)
 }
@@ -89,6 +174,16 @@ func Fatalf(msg string, args ...interface{}) {
 	std.Fatalf(msg, args...)
 }
 
+// FatalWithCode is like Fatal, but exits with the given code.
+func FatalWithCode(code int, msg ...interface{}) {
+	std.FatalWithCode(code, msg...)
+}
+
+// FatalfWithCode is like Fatalf, but exits with the given code.
+func FatalfWithCode(code int, msg string, args ...interface{}) {
+	std.FatalfWithCode(code, msg, args...)
+}
+
 func Error(msg ...interface{}) {
 	std.Error(msg...)
 }
@@ -24,6 +24,30 @@ func TestLogger_std_WithFields(t *testing.T) {
 	testLoggerWithFields(t, std, WithFields)
 }
 
+func TestLogger_std_WithCallerSkip(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerWithCallerSkip(t, std, WithCallerSkip)
+}
+
+func TestLogger_std_WithBacktrace(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerWithBacktrace(t, std, WithBacktrace)
+}
+
+func TestLogger_std_WithGroup(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerWithGroup(t, std, WithGroup)
+}
+
 func TestLogger_std_SetFields(t *testing.T) {
 	acquireStd()
 
@@ -48,6 +72,14 @@ func TestLogger_std_SetLevel(t *testing.T) {
 	testLoggerSetLevel(t, std, SetLevel)
 }
 
+func TestLogger_std_SetLocation(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerSetLocation(t, std, SetLocation)
+}
+
 func TestLogger_std_SetOutput(t *testing.T) {
 	acquireStd()
 
@@ -56,6 +88,46 @@ func TestLogger_std_SetOutput(t *testing.T) {
 	testLoggerSetOutput(t, std, SetOutput)
 }
 
+func TestLogger_std_SetClock(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerSetClock(t, std, SetClock)
+}
+
+func TestLogger_std_SetBacktrace(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerSetBacktrace(t, std, SetBacktrace)
+}
+
+func TestLogger_std_SetPanicMode(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerSetPanicMode(t, std, SetPanicMode)
+}
+
+func TestLogger_std_SetExitTimeout(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerSetExitTimeout(t, std, SetExitTimeout)
+}
+
+func TestLogger_std_FatalWithCode(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerFatalWithCode(t, std, FatalWithCode, FatalfWithCode)
+}
+
 func TestLogger_std_SetEncoder(t *testing.T) {
 	acquireStd()
 
@@ -64,6 +136,14 @@ func TestLogger_std_SetEncoder(t *testing.T) {
 	testLoggerSetEncoder(t, std, SetEncoder)
 }
 
+func TestLogger_std_SetRedactor(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerSetRedactor(t, std, SetRedactor)
+}
+
 func TestLogger_std_IsLevelEnabled(t *testing.T) {
 	acquireStd()
 
@@ -80,6 +160,38 @@ func TestLogger_std_AddHook(t *testing.T) {
 	testLoggerAddHook(t, std, AddHook)
 }
 
+func TestLogger_std_AddProcessor(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerAddProcessor(t, std, AddProcessor)
+}
+
+func TestLogger_std_LogFields(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerLogFields(t, std, LogFields)
+}
+
+func TestLogger_std_Sync(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerSync(t, std, Sync)
+}
+
+func TestLogger_std_Close(t *testing.T) {
+	acquireStd()
+
+	defer releaseStd()
+
+	testLoggerClose(t, std, Close)
+}
+
 func TestLogger_std_Levels(t *testing.T) { // nolint:funlen
 	acquireStd()
 
@@ -177,6 +289,50 @@ func TestLogger_std_Levels(t *testing.T) { // nolint:funlen
 				exitCode: -1,
 			},
 		},
+		{
+			name: "Log",
+			args: testLoggerLevelArgs{
+				log:  Log,
+				logf: Logf,
+			},
+			want: testLoggerLevelWant{
+				level:    testLevelNotice,
+				exitCode: -1,
+			},
+		},
+		{
+			name: "LogExit",
+			args: testLoggerLevelArgs{
+				log:  Log,
+				logf: Logf,
+			},
+			want: testLoggerLevelWant{
+				level:    testLevelAlert,
+				exitCode: 1,
+			},
+		},
+		{
+			name: "LogPanic",
+			args: testLoggerLevelArgs{
+				log:  Log,
+				logf: Logf,
+			},
+			want: testLoggerLevelWant{
+				level:    testLevelEmerg,
+				exitCode: -1,
+			},
+		},
+		{
+			name: "LogBuiltin",
+			args: testLoggerLevelArgs{
+				log:  Log,
+				logf: Logf,
+			},
+			want: testLoggerLevelWant{
+				level:    INFO,
+				exitCode: -1,
+			},
+		},
 	}
 
 	testLoggerLevels(t, std, testCases)
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"io"
+	"log"
+	"strings"
+)
+
+// NewStdLogWriter returns a writer which forwards the lines written by a *log.Logger to the given logger,
+// trimming the trailing newline.
+//
+// The lines are logged with the current state of the given logger, like its level, output and fields,
+// so the later changes apply too.
+//
+// The frames of the Print, Fatal and Panic functions of the log package are skipped when getting the caller,
+// so the file and function flags report their callers.
+//
+// NOTE: The lines with FATAL or PANIC level don't exit or panic,
+// since the *log.Logger already does it for the Fatal and Panic methods.
+func NewStdLogWriter(l *Logger, cfg StdLogConfig) io.Writer {
+	return &stdLogWriter{l: l, cfg: cfg}
+}
+
+// NewStdLog returns a *log.Logger which forwards the lines to the given logger,
+// like the ErrorLog of net/http.Server.
+func NewStdLog(l *Logger, cfg StdLogConfig) *log.Logger {
+	return log.New(NewStdLogWriter(l, cfg), "", 0)
+}
+
+// RedirectStdLog redirects the output of the log package to the given logger,
+// and returns a function to restore the previous output, prefix and flags.
+func RedirectStdLog(l *Logger, cfg StdLogConfig) func() {
+	output, prefix, flags := log.Writer(), log.Prefix(), log.Flags()
+
+	log.SetOutput(NewStdLogWriter(l, cfg))
+	log.SetPrefix("")
+	log.SetFlags(0)
+
+	return func() {
+		log.SetOutput(output)
+		log.SetPrefix(prefix)
+		log.SetFlags(flags)
+	}
+}
+
+// Write logs the line with the configured or parsed level.
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	line := strings.TrimSuffix(string(p), "\n")
+	level, msg := w.parseLevel(line)
+
+	w.l.mu.RLock()
+	w.l.encodeOutputDepth(w.l.cfg.calldepth+calldepthStdLog, level, "", []interface{}{msg}, nil)
+	w.l.mu.RUnlock()
+
+	return len(p), nil
+}
+
+// parseLevel returns the level of the line prefix and the rest of the line, if enabled.
+// Otherwise, returns the configured level and the line.
+func (w *stdLogWriter) parseLevel(line string) (Level, string) {
+	if !w.cfg.ParseLevel {
+		return w.cfg.Level, line
+	}
+
+	var name, rest string
+
+	if strings.HasPrefix(line, "[") {
+		end := strings.IndexByte(line, ']')
+		if end < 0 {
+			return w.cfg.Level, line
+		}
+
+		name, rest = line[1:end], line[end+1:]
+	} else {
+		end := strings.IndexByte(line, ':')
+		if end < 0 {
+			return w.cfg.Level, line
+		}
+
+		name, rest = line[:end], line[end+1:]
+	}
+
+	if name == "" {
+		return w.cfg.Level, line
+	}
+
+	level, err := ParseLevel(name)
+	if err != nil {
+		return w.cfg.Level, line
+	}
+
+	return level, strings.TrimPrefix(rest, " ")
+}
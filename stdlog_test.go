@@ -0,0 +1,257 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestNewStdLogWriter(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	l := New(INFO, output)
+	l.SetFlags(0)
+
+	w := NewStdLogWriter(l, StdLogConfig{Level: WARNING})
+
+	if n, err := w.Write([]byte("hello\n")); err != nil || n != 6 {
+		t.Errorf("Write() == (%d, %v), want (%d, nil)", n, err, 6)
+	}
+
+	w = NewStdLogWriter(l, StdLogConfig{Level: DEBUG})
+	w.Write([]byte("disabled\n")) // nolint:errcheck
+
+	if want := "WARNING - hello\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestNewStdLog(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	l := New(INFO, output)
+	l.SetFlags(0)
+
+	stdLog := NewStdLog(l, StdLogConfig{Level: ERROR})
+
+	if stdLog.Flags() != 0 || stdLog.Prefix() != "" {
+		t.Errorf("flags/prefix == %d/%q, want 0/%q", stdLog.Flags(), stdLog.Prefix(), "")
+	}
+
+	stdLog.Printf("http: %s", "TLS handshake error")
+	stdLog.Println("multi\nline")
+
+	want := "ERROR - http: TLS handshake error\nERROR - multi\\nline\n"
+
+	if output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestNewStdLog_caller(t *testing.T) {
+	l := newTestLogger()
+
+	entry := testLoggerCallerEntry(l, func() {
+		stdLog := NewStdLog(l, StdLogConfig{Level: INFO})
+		stdLog.Print("hello")
+	})
+
+	wantFunction := "github.com/savsgio/go-logger/v4.TestNewStdLog_caller.func1"
+	if entry.Caller.Function != wantFunction {
+		t.Errorf("caller function == %s, want %s", entry.Caller.Function, wantFunction)
+	}
+
+	if !strings.HasSuffix(entry.Caller.File, "stdlog_test.go") {
+		t.Errorf("caller file == %s, want %s", entry.Caller.File, "stdlog_test.go")
+	}
+
+	if entry.Message != "hello" {
+		t.Errorf("message == %q, want %q", entry.Message, "hello")
+	}
+}
+
+func TestNewStdLog_shared(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	l := New(ERROR, io.Discard)
+	l.SetFlags(0)
+
+	stdLog := NewStdLog(l, StdLogConfig{Level: INFO})
+
+	// The later changes of the logger apply to the standard library logger too.
+	l.SetLevel(INFO)
+	l.SetOutput(output)
+	l.SetFields(String("component", "http"))
+
+	stdLog.Print("hello")
+
+	if want := "INFO - component=http - hello\n"; output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+}
+
+func TestRedirectStdLog_caller(t *testing.T) {
+	l := newTestLogger()
+
+	entry := testLoggerCallerEntry(l, func() {
+		defer RedirectStdLog(l, StdLogConfig{Level: INFO})()
+
+		log.Printf("hello %s", "world")
+	})
+
+	wantFunction := "github.com/savsgio/go-logger/v4.TestRedirectStdLog_caller.func1"
+	if entry.Caller.Function != wantFunction {
+		t.Errorf("caller function == %s, want %s", entry.Caller.Function, wantFunction)
+	}
+
+	// The log package functions are skipped with the calldepth, instead of marking them as helpers.
+	if helpers.isHelper("log.Printf") || helpers.isHelper("log.(*Logger).output") {
+		t.Error("the log package functions are marked as helpers")
+	}
+}
+
+func testStdLogCallerSkip(l *log.Logger) {
+	l.Printf("hello %s", "world")
+}
+
+func TestNewStdLog_callerSkip(t *testing.T) {
+	l := newTestLogger()
+
+	entry := testLoggerCallerEntry(l, func() {
+		testStdLogCallerSkip(NewStdLog(l.WithCallerSkip(1), StdLogConfig{Level: INFO}))
+	})
+
+	wantFunction := "github.com/savsgio/go-logger/v4.TestNewStdLog_callerSkip.func1"
+	if entry.Caller.Function != wantFunction {
+		t.Errorf("caller function == %s, want %s", entry.Caller.Function, wantFunction)
+	}
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	output := new(bytes.Buffer)
+
+	l := New(INFO, output)
+	l.SetFlags(0)
+
+	prevOutput, prevPrefix, prevFlags := log.Writer(), log.Prefix(), log.Flags()
+
+	restore := RedirectStdLog(l, StdLogConfig{Level: INFO, ParseLevel: true})
+
+	log.Print("[ERROR] failed")
+	log.Printf("hello %s", "world")
+
+	restore()
+
+	want := "ERROR - failed\nINFO - hello world\n"
+
+	if output.String() != want {
+		t.Errorf("output == %q, want %q", output.String(), want)
+	}
+
+	if log.Writer() != prevOutput || log.Prefix() != prevPrefix || log.Flags() != prevFlags {
+		t.Error("the log package output, prefix and flags are not restored")
+	}
+}
+
+func Test_stdLogWriter_parseLevel(t *testing.T) { // nolint:funlen
+	type args struct {
+		cfg  StdLogConfig
+		line string
+	}
+
+	type want struct {
+		level Level
+		msg   string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "Disabled",
+			args: args{
+				cfg:  StdLogConfig{Level: INFO},
+				line: "[ERROR] failed",
+			},
+			want: want{level: INFO, msg: "[ERROR] failed"},
+		},
+		{
+			name: "Brackets",
+			args: args{
+				cfg:  StdLogConfig{Level: INFO, ParseLevel: true},
+				line: "[ERROR] failed",
+			},
+			want: want{level: ERROR, msg: "failed"},
+		},
+		{
+			name: "BracketsLowercase",
+			args: args{
+				cfg:  StdLogConfig{Level: INFO, ParseLevel: true},
+				line: "[warn] slow",
+			},
+			want: want{level: WARNING, msg: "slow"},
+		},
+		{
+			name: "Colon",
+			args: args{
+				cfg:  StdLogConfig{Level: INFO, ParseLevel: true},
+				line: "DEBUG: details",
+			},
+			want: want{level: DEBUG, msg: "details"},
+		},
+		{
+			name: "UnknownLevel",
+			args: args{
+				cfg:  StdLogConfig{Level: INFO, ParseLevel: true},
+				line: "http: TLS handshake error",
+			},
+			want: want{level: INFO, msg: "http: TLS handshake error"},
+		},
+		{
+			name: "EmptyLevel",
+			args: args{
+				cfg:  StdLogConfig{Level: INFO, ParseLevel: true},
+				line: "[] hello",
+			},
+			want: want{level: INFO, msg: "[] hello"},
+		},
+		{
+			name: "Unclosed",
+			args: args{
+				cfg:  StdLogConfig{Level: INFO, ParseLevel: true},
+				line: "[ERROR failed",
+			},
+			want: want{level: INFO, msg: "[ERROR failed"},
+		},
+		{
+			name: "NoPrefix",
+			args: args{
+				cfg:  StdLogConfig{Level: INFO, ParseLevel: true},
+				line: "hello",
+			},
+			want: want{level: INFO, msg: "hello"},
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			w := &stdLogWriter{cfg: test.args.cfg}
+
+			level, msg := w.parseLevel(test.args.line)
+
+			if level != test.want.level {
+				t.Errorf("level == %s, want %s", level, test.want.level)
+			}
+
+			if msg != test.want.msg {
+				t.Errorf("msg == %q, want %q", msg, test.want.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Sync flushes and commits the buffered data of the processors, output and hooks
+// which implement Flusher or Syncer.
+//
+// The processors are flushed first, so the entries they emit are also committed.
+//
+// The errors are returned as a MultiError.
+func (l *Logger) Sync() error {
+	l.mu.RLock()
+	output := l.output
+	hooks := l.hooks.all
+	processors := l.processors
+	l.mu.RUnlock()
+
+	var errs MultiError
+
+	for i, p := range processors {
+		if err := syncOrFlush(p); err != nil {
+			errs = append(errs, fmt.Errorf("failed to sync processor[%d]: %w", i, err))
+		}
+	}
+
+	if err := syncOrFlush(output); err != nil && !isSyncUnsupported(err) {
+		errs = append(errs, fmt.Errorf("failed to sync output: %w", err))
+	}
+
+	for i, h := range hooks {
+		if err := syncOrFlush(h); err != nil {
+			errs = append(errs, fmt.Errorf("failed to sync hook[%d]: %w", i, err))
+		}
+	}
+
+	return errs.err()
+}
+
+// Close syncs the logger, and closes the processors, output and hooks which implement io.Closer,
+// except the standard output and error.
+//
+// The errors are returned as a MultiError.
+//
+// NOTE: The logger copies share the output and hooks, so don't use them after closing.
+func (l *Logger) Close() error {
+	var errs MultiError
+
+	if err := l.Sync(); err != nil {
+		errs = append(errs, err.(MultiError)...) // nolint:errorlint,forcetypeassert
+	}
+
+	l.mu.RLock()
+	output := l.output
+	hooks := l.hooks.all
+	processors := l.processors
+	l.mu.RUnlock()
+
+	for i, p := range processors {
+		if c, ok := p.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close processor[%d]: %w", i, err))
+			}
+		}
+	}
+
+	if c, ok := output.(io.Closer); ok && !isStdOutput(output) {
+		if err := c.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close output: %w", err))
+		}
+	}
+
+	for i, h := range hooks {
+		if c, ok := h.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close hook[%d]: %w", i, err))
+			}
+		}
+	}
+
+	return errs.err()
+}
+
+// syncOrFlush flushes and syncs the given value, if it implements Flusher or Syncer.
+func syncOrFlush(v interface{}) error {
+	if f, ok := v.(Flusher); ok {
+		if err := f.Flush(); err != nil {
+			return err // nolint:wrapcheck
+		}
+	}
+
+	if s, ok := v.(Syncer); ok {
+		return s.Sync() // nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// isSyncUnsupported returns whether the error is because the output doesn't support syncing,
+// like the terminals and pipes.
+func isSyncUnsupported(err error) bool {
+	for _, target := range syncUnsupportedErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isStdOutput(output io.Writer) bool {
+	return output == os.Stdout || output == os.Stderr
+}
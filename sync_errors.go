@@ -0,0 +1,10 @@
+//go:build !plan9
+// +build !plan9
+
+package logger
+
+import "syscall"
+
+// syncUnsupportedErrors are the errors returned by syncing an output which doesn't support it,
+// like EINVAL on Linux, or ENOTTY and ENOTSUP on macOS.
+var syncUnsupportedErrors = []error{syscall.EINVAL, syscall.ENOTTY, syscall.ENOTSUP}
@@ -0,0 +1,6 @@
+package logger
+
+import "syscall"
+
+// syncUnsupportedErrors are the errors returned by syncing an output which doesn't support it.
+var syncUnsupportedErrors = []error{syscall.EINVAL}
@@ -0,0 +1,196 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+type mockFlushSyncer struct {
+	io.Writer
+
+	flushed int
+	synced  int
+	closed  int
+	err     error
+}
+
+func (m *mockFlushSyncer) Flush() error {
+	m.flushed++
+
+	return m.err
+}
+
+func (m *mockFlushSyncer) Sync() error {
+	m.synced++
+
+	return nil
+}
+
+func (m *mockFlushSyncer) Close() error {
+	m.closed++
+
+	return m.err
+}
+
+type mockFlushHook struct {
+	mockFlushSyncer
+}
+
+func (h *mockFlushHook) Levels() []Level {
+	return []Level{ERROR}
+}
+
+func (h *mockFlushHook) Fire(_ Entry) error {
+	return nil
+}
+
+type mockFlushProcessor struct {
+	mockFlushSyncer
+}
+
+func (p *mockFlushProcessor) Process(_ *Entry) bool {
+	return true
+}
+
+func Test_syncOrFlush(t *testing.T) {
+	errFlush := errors.New("flush error")
+
+	m := &mockFlushSyncer{err: errFlush}
+
+	if err := syncOrFlush(m); !errors.Is(err, errFlush) {
+		t.Errorf("error == %v, want %v", err, errFlush)
+	}
+
+	if m.synced != 0 {
+		t.Errorf("synced == %d, want %d", m.synced, 0)
+	}
+
+	if err := syncOrFlush(io.Discard); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func testLoggerSync(t *testing.T, l *Logger, syncFunc func() error) {
+	t.Helper()
+
+	output := &mockFlushSyncer{Writer: new(bytes.Buffer)}
+	hook := new(mockFlushHook)
+	processor := new(mockFlushProcessor)
+
+	l.SetOutput(output)
+	l.AddHook(hook) // nolint:errcheck
+	l.AddProcessor(processor)
+
+	if err := syncFunc(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.flushed != 1 || output.synced != 1 {
+		t.Errorf("output flushed/synced == %d/%d, want 1/1", output.flushed, output.synced)
+	}
+
+	if hook.flushed != 1 || hook.synced != 1 {
+		t.Errorf("hook flushed/synced == %d/%d, want 1/1", hook.flushed, hook.synced)
+	}
+
+	if processor.flushed != 1 || processor.synced != 1 {
+		t.Errorf("processor flushed/synced == %d/%d, want 1/1", processor.flushed, processor.synced)
+	}
+
+	errFlush := errors.New("flush error")
+	output.err = errFlush
+	hook.err = errFlush
+	processor.err = errFlush
+
+	err := syncFunc()
+
+	var multiErr MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("error == %T, want %T", err, multiErr)
+	}
+
+	if len(multiErr) != 3 {
+		t.Errorf("errors == %d, want %d", len(multiErr), 3)
+	}
+
+	if !errors.Is(err, errFlush) {
+		t.Errorf("errors.Is(%v, %v) == false, want true", err, errFlush)
+	}
+}
+
+func TestLogger_Sync(t *testing.T) {
+	l := newTestLogger()
+	testLoggerSync(t, l, l.Sync)
+}
+
+func testLoggerClose(t *testing.T, l *Logger, closeFunc func() error) {
+	t.Helper()
+
+	output := &mockFlushSyncer{Writer: new(bytes.Buffer)}
+	hook := new(mockFlushHook)
+	processor := new(mockFlushProcessor)
+
+	l.SetOutput(output)
+	l.AddHook(hook) // nolint:errcheck
+	l.AddProcessor(processor)
+
+	if err := closeFunc(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output.synced != 1 || output.closed != 1 {
+		t.Errorf("output synced/closed == %d/%d, want 1/1", output.synced, output.closed)
+	}
+
+	if hook.synced != 1 || hook.closed != 1 {
+		t.Errorf("hook synced/closed == %d/%d, want 1/1", hook.synced, hook.closed)
+	}
+
+	if processor.synced != 1 || processor.closed != 1 {
+		t.Errorf("processor synced/closed == %d/%d, want 1/1", processor.synced, processor.closed)
+	}
+
+	errClose := errors.New("close error")
+	output.err = errClose
+
+	// The flush and close errors of the output.
+	if err := closeFunc(); !errors.Is(err, errClose) || len(err.(MultiError)) != 2 { // nolint:errorlint
+		t.Errorf("error == %v, want 2 errors of %v", err, errClose)
+	}
+
+	l.SetOutput(os.Stderr)
+
+	if err := closeFunc(); err != nil && !errors.Is(err, errClose) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestLogger_Close(t *testing.T) {
+	l := newTestLogger()
+	testLoggerClose(t, l, l.Close)
+}
+
+func Test_isStdOutput(t *testing.T) {
+	if !isStdOutput(os.Stdout) || !isStdOutput(os.Stderr) {
+		t.Error("the standard outputs are not detected")
+	}
+
+	if isStdOutput(io.Discard) {
+		t.Error("io.Discard is detected as standard output")
+	}
+}
+
+func Test_isSyncUnsupported(t *testing.T) {
+	for _, err := range syncUnsupportedErrors {
+		if !isSyncUnsupported(&os.PathError{Op: "sync", Path: "/dev/stderr", Err: err}) {
+			t.Errorf("the error %v is not detected as unsupported", err)
+		}
+	}
+
+	if isSyncUnsupported(errors.New("sync failed")) {
+		t.Error("the unknown error is detected as unsupported")
+	}
+}
@@ -2,8 +2,10 @@ package logger
 
 import (
 	"io"
+	"regexp"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/valyala/bytebufferpool"
@@ -11,7 +13,29 @@ import (
 
 type exitFunc func(code int)
 
+type helperRegistry struct {
+	pcs   sync.Map // map[uintptr]struct{}, the program counters already marked
+	funcs sync.Map // map[string]struct{}, the marked function names
+	count int32
+}
+
+// callerFrame is a caller frame cached by program counter.
+//
+// It's immutable, so it's replaced when the helpers change.
+type callerFrame struct {
+	frame     runtime.Frame
+	shortFile string // the file name without the directories
+	helper    bool
+	helperGen int32 // the helpers count when helper was checked
+}
+
+type exitHandlerRegistry struct {
+	mu       sync.Mutex
+	handlers []func()
+}
+
 type levelHooks struct {
+	all       []Hook // unique hooks, in the registration order
 	store     map[Level][]Hook
 	errOutput io.Writer
 }
@@ -19,24 +43,226 @@ type levelHooks struct {
 // Level type.
 type Level int
 
+// LevelFilter decides which levels are enabled.
+//
+// A Level is also a filter, which enables the levels with lower or equal severity.
+type LevelFilter interface {
+	Enabled(level Level) bool
+}
+
+// LevelFilterFunc is an adapter to use ordinary functions as level filters.
+type LevelFilterFunc func(level Level) bool
+
+// LevelExact is a level filter which only enables the given level.
+type LevelExact Level
+
+// LevelRange is a level filter which enables the levels whose severity is between
+// the severities of both levels, included, in any order.
+type LevelRange struct {
+	From Level
+	To   Level
+}
+
+// LevelSet is a level filter which only enables the levels of the set.
+type LevelSet map[Level]struct{}
+
+// LevelWriter is a writer which also receives the level of the written entry.
+//
+// If the logger output implements it, WriteLevel is called instead of Write.
+type LevelWriter interface {
+	io.Writer
+
+	WriteLevel(level Level, p []byte) (int, error)
+}
+
+// LevelFilterWriter is a writer which only writes the entries enabled by the filter.
+type LevelFilterWriter struct {
+	filter LevelFilter
+	w      io.Writer
+}
+
+// LevelRoute routes the entries enabled by the filter to the writer.
+type LevelRoute struct {
+	Filter LevelFilter
+	Writer io.Writer
+}
+
+// LevelRouter is a writer which writes each entry to the writers of the routes
+// whose filter enables the entry level.
+type LevelRouter struct {
+	routes []LevelRoute
+}
+
+// BufferedWriterConfig is the configuration of the buffered writer.
+type BufferedWriterConfig struct {
+	// Size is the buffered size which triggers a flush when reached.
+	//
+	// Default: 256KiB
+	Size int
+
+	// FlushInterval is the period to flush the buffered data.
+	// A negative value disables the periodic flush.
+	//
+	// Default: 1s
+	FlushInterval time.Duration
+
+	// FlushLevel flushes the buffered data immediately after writing an entry
+	// whose level is enabled by the filter.
+	//
+	// Default: LevelRange{From: PANIC, To: ERROR}
+	FlushLevel LevelFilter
+}
+
+// BufferedWriter is a writer which accumulates the entries in memory,
+// and writes them to the underlying writer in batches.
+//
+// Use it as the logger output to reduce the number of write syscalls.
+//
+// If the underlying writer is a LevelWriter, like a LevelRouter or a LevelFilterWriter,
+// the buffered data is written with the level of each entry.
+type BufferedWriter struct {
+	mu        sync.Mutex
+	cfg       BufferedWriterConfig
+	w         io.Writer
+	lw        LevelWriter
+	buf       []byte
+	segments  []bufferedSegment
+	errOutput io.Writer
+	ticker    *time.Ticker
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// bufferedSegment is the end of the consecutive buffered data with the same level,
+// or invalid if unknown, written to a LevelWriter.
+type bufferedSegment struct {
+	level Level
+	end   int
+}
+
+// LevelConfig is the configuration of a custom level.
+type LevelConfig struct {
+	// Name is the level name, like NOTICE, which must be unique.
+	// It's case insensitive and encoded in uppercase.
+	Name string
+
+	// Severity is the position of the level relative to the built-in levels,
+	// like WARNING.Severity() + 5 to place it between WARNING and INFO.
+	//
+	// The level is enabled when its severity is lower or equal than the logger level one.
+	Severity int
+
+	// Exit exits with code 1 after logging, like FATAL.
+	Exit bool
+
+	// Panic panics after logging, like PANIC.
+	Panic bool
+}
+
+type customLevel struct {
+	cfg     LevelConfig
+	lower   string
+	short   string
+	numeric string
+}
+
+type levelRegistry struct {
+	mu     sync.Mutex
+	levels atomic.Value // map[Level]*customLevel, replaced on every registration
+	next   Level
+}
+
+// LevelFormat type.
+type LevelFormat int
+
 // Flag type.
 type Flag int
 
 // Field type.
+//
+// Use the typed constructors, like String or Int64, to avoid the reflection when encoding.
+// Their Value is also set, but the encoders use the typed members.
 type Field struct {
 	Key   string
 	Value interface{}
+
+	typ     fieldType
+	integer int64
+	str     string
+	loc     *time.Location
+}
+
+type fieldType uint8
+
+// LogValuer represents a value which is only evaluated when it's going to be logged,
+// so the expensive values are not built if the level is disabled.
+type LogValuer interface {
+	// LogValue returns the value to log.
+	LogValue() interface{}
+}
+
+// LogValuerFunc is an adapter to allow the use of ordinary functions as log valuers.
+type LogValuerFunc func() interface{}
+
+// ObjectMarshaler allows the values to encode themselves as objects, without reflection.
+type ObjectMarshaler interface {
+	// MarshalLogObject adds the object values to the given encoder.
+	//
+	// NOTE: If an error is returned, the error message is encoded instead of the object.
+	MarshalLogObject(enc ObjectEncoder) error
+}
+
+// ArrayMarshaler allows the values to encode themselves as arrays, without reflection.
+type ArrayMarshaler interface {
+	// MarshalLogArray appends the array values to the given encoder.
+	//
+	// NOTE: If an error is returned, the error message is encoded instead of the array.
+	MarshalLogArray(enc ArrayEncoder) error
+}
+
+// ObjectEncoder is the encoder used by the ObjectMarshaler values.
+type ObjectEncoder interface {
+	AddString(key, value string)
+	AddInt64(key string, value int64)
+	AddFloat64(key string, value float64)
+	AddBool(key string, value bool)
+	AddDuration(key string, value time.Duration)
+	AddTime(key string, value time.Time)
+	AddObject(key string, value ObjectMarshaler)
+	AddArray(key string, value ArrayMarshaler)
+	AddAny(key string, value interface{})
+}
+
+// ArrayEncoder is the encoder used by the ArrayMarshaler values.
+type ArrayEncoder interface {
+	AppendString(value string)
+	AppendInt64(value int64)
+	AppendFloat64(value float64)
+	AppendBool(value bool)
+	AppendDuration(value time.Duration)
+	AppendTime(value time.Time)
+	AppendObject(value ObjectMarshaler)
+	AppendArray(value ArrayMarshaler)
+	AppendAny(value interface{})
 }
 
 // Buffer provides the byte buffer used by encoders to encode the output.
 type Buffer struct {
 	b1 bytebufferpool.ByteBuffer
 	b2 bytebufferpool.ByteBuffer
+
+	pcs []uintptr // reused to get the caller, kept on reset
 }
 
 // TimestampFormat type.
 type TimestampFormat int
 
+// KeyCollisionPolicy type.
+type KeyCollisionPolicy int
+
+// TextEscapeMode type.
+type TextEscapeMode int
+
 // Entry collects all the information for the output.
 type Entry struct {
 	Config     Config
@@ -46,6 +272,11 @@ type Entry struct {
 	Message    string
 	RawMessage string
 	Args       []interface{}
+
+	// Fields are the entry fields, encoded after the logger ones.
+	Fields []Field
+
+	callerShortFile string
 }
 
 // Config is the logger configuration.
@@ -58,21 +289,152 @@ type Config struct {
 	Longfile  bool
 	Function  bool
 
+	// Location is the time zone of the datetime and timestamp.
+	// If nil, the UTC flag decides between UTC and the local time zone.
+	Location *time.Location
+
 	flag      Flag
 	calldepth int
+	lazy      bool
 }
 
 // Logger type.
 type Logger struct {
-	mu      sync.RWMutex // ensures atomic writes; protects the following fields
-	cfg     Config
-	level   Level
-	output  io.Writer
-	encoder Encoder
-	hooks   *levelHooks
-	exit    exitFunc
+	mu          sync.RWMutex // ensures atomic writes; protects the following fields
+	cfg         Config
+	level       LevelFilter
+	output      io.Writer
+	encoder     Encoder
+	hooks       *levelHooks
+	processors  []Processor
+	redactor    *Redactor
+	clock       Clock
+	panicMode   PanicMode
+	exitTimeout time.Duration
+	exit        exitFunc
+	backtrace   *Backtrace
+}
+
+// BacktraceConfig is the configuration of the backtrace.
+type BacktraceConfig struct {
+	// Level records the entries disabled by the logger level whose level is enabled by the filter.
+	//
+	// Default: TRACE
+	Level LevelFilter
+
+	// FlushLevel writes the recorded entries to the output before an entry
+	// whose level is enabled by the filter.
+	//
+	// Default: LevelRange{From: PANIC, To: ERROR}
+	FlushLevel LevelFilter
+
+	// Size is the maximum number of recorded entries, so the oldest ones are discarded.
+	//
+	// Default: 100
+	Size int
+
+	// MaxBytes is the maximum size of the recorded entries, so the oldest ones are discarded.
+	//
+	// Default: 64KiB
+	MaxBytes int
+}
+
+// Backtrace is a ring buffer, like a flight recorder, which records the encoded entries
+// disabled by the logger level, and writes them to the output when an error occurs.
+type Backtrace struct {
+	mu      sync.Mutex
+	cfg     BacktraceConfig
+	entries []backtraceEntry
+	head    int
+	count   int
+	bytes   int
+}
+
+type backtraceEntry struct {
+	level Level
+	data  []byte
+}
+
+// StdLogConfig is the configuration of the standard library log bridge.
+type StdLogConfig struct {
+	// Level is the level of the lines.
+	//
+	// Default: PRINT
+	Level Level
+
+	// ParseLevel parses the level from the prefix of the lines, like "[ERROR] " or "ERROR: ",
+	// so the lines without a known level prefix keep the configured level.
+	ParseLevel bool
+}
+
+// stdLogWriter forwards the lines written by a *log.Logger to the logger.
+type stdLogWriter struct {
+	l   *Logger
+	cfg StdLogConfig
+}
+
+// Clock represents the source of the entries time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to use ordinary functions as clocks.
+type ClockFunc func() time.Time
+
+// FakeClock is a clock which always returns the same time until it's changed.
+//
+// Useful for deterministic tests.
+type FakeClock struct {
+	mu sync.RWMutex
+	t  time.Time
 }
 
+// CoarseClock is a clock which caches the current time and updates it periodically,
+// so it's cheaper than time.Now at the cost of precision.
+type CoarseClock struct {
+	now      atomic.Value
+	ticker   *time.Ticker
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// PanicMode type.
+type PanicMode int
+
+// PanicError is the value of the panics raised by the PANIC entries, with PanicModeError.
+type PanicError struct {
+	// Message is the formatted message.
+	Message string
+
+	// Level is the entry level.
+	Level Level
+
+	// Fields are the logger fields followed by the entry fields.
+	Fields []Field
+
+	// Caller is the entry caller.
+	Caller runtime.Frame
+
+	// Err is the first error of the message args, if any.
+	Err error
+}
+
+// Syncer is implemented by the outputs and hooks which could commit their data,
+// like *os.File.
+type Syncer interface {
+	Sync() error
+}
+
+// Flusher is implemented by the outputs and hooks which buffer their data,
+// like *bufio.Writer.
+type Flusher interface {
+	Flush() error
+}
+
+// MultiError is a list of errors, like the ones returned when syncing or closing the logger.
+type MultiError []error
+
 // Hook represents a extended functionality that will be fired when logging.
 //
 // NOTE: This is not run concurrently, so be quite with locks.
@@ -86,6 +448,176 @@ type Hook interface {
 	Fire(e Entry) error
 }
 
+// Processor represents a stage that will be run before encoding,
+// so it could enrich, rewrite or drop the entry.
+//
+// NOTE: The entry changes are also visible for the hooks.
+// The logger fields in Config.Fields are shared with the logger, so replace the slice
+// with a modified copy instead of modifying its elements, to get them encoded with the entry.
+type Processor interface {
+	// Process is processor function.
+	//
+	// NOTE: Return false to drop the entry.
+	Process(e *Entry) bool
+}
+
+// ProcessorFunc is an adapter to allow the use of ordinary functions as processors.
+type ProcessorFunc func(e *Entry) bool
+
+// EmitFunc encodes and writes an entry emitted by a processor, without processing it.
+type EmitFunc func(e Entry)
+
+// EmitProcessor is a processor which could emit additional entries, like summaries.
+type EmitProcessor interface {
+	Processor
+
+	// ProcessEmit is called by the logger instead of Process,
+	// with the function to emit entries before the processed one.
+	ProcessEmit(e *Entry, emit EmitFunc) bool
+
+	// SetEmit is called by the logger when adding the processor,
+	// with the function to emit entries out of the processing, like from timers.
+	SetEmit(emit EmitFunc)
+}
+
+// DedupConfig is the configuration of the dedup processor.
+type DedupConfig struct {
+	// Window is the maximum time to collapse the repeated entries, since the first one.
+	//
+	// Default: 1s
+	Window time.Duration
+
+	// Summary returns the entry emitted for the repeated entries, from the first one.
+	//
+	// Default: the first entry with the message "message repeated N times" and the field "repeated"
+	Summary func(e Entry, repeated int) Entry
+
+	// Clock is the source of the time to check the window.
+	//
+	// Default: the system clock
+	Clock Clock
+}
+
+// Dedup is a processor which collapses the identical consecutive entries,
+// with the same level, message and fields, within a time window into the first one
+// plus a summary, emitted when the window closes or a different entry arrives.
+type Dedup struct {
+	mu       sync.Mutex
+	cfg      DedupConfig
+	emit     EmitFunc
+	last     Entry
+	hasLast  bool
+	since    time.Time
+	repeated int
+	gen      int
+	timer    *time.Timer
+}
+
+// RateLimiterConfig is the configuration of the rate limiter processor.
+type RateLimiterConfig struct {
+	// Rate is the number of entries allowed per second, for each level or key value.
+	//
+	// Default: 100
+	Rate float64
+
+	// Burst is the maximum number of entries allowed at once, for each level or key value.
+	//
+	// Default: the rate, at least 1
+	Burst int
+
+	// Filter limits only the entries whose level is enabled by the filter,
+	// so the rest are always allowed.
+	//
+	// Default: all the levels
+	Filter LevelFilter
+
+	// Key is the field key whose values are limited separately, like "client_ip",
+	// instead of each level. The entries without the field are not limited.
+	Key string
+
+	// SummaryInterval is the period to emit the summary of the suppressed entries.
+	//
+	// Default: 10s
+	SummaryInterval time.Duration
+
+	// Clock is the source of the time to refill the tokens.
+	//
+	// Default: the system clock
+	Clock Clock
+}
+
+// RateLimiter is a processor which limits the entries of each level, or each value of a field,
+// with a token bucket, and periodically emits a summary with the number of suppressed entries.
+type RateLimiter struct {
+	mu        sync.Mutex
+	cfg       RateLimiterConfig
+	emit      EmitFunc
+	buckets   map[rateLimitKey]*rateLimitBucket
+	ticker    *time.Ticker
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type rateLimitKey struct {
+	level Level
+	value string
+}
+
+type rateLimitBucket struct {
+	tokens     float64
+	last       time.Time
+	suppressed int
+	entry      Entry // first suppressed entry since the last summary
+}
+
+// Redactable represents a value which knows how to redact itself.
+type Redactable interface {
+	// Redacted returns the value safe to be logged.
+	Redacted() interface{}
+}
+
+// RedactorConfig is the configuration of the redactor.
+type RedactorConfig struct {
+	// Keys of the fields whose values will be replaced (case-insensitive).
+	//
+	// Glob patterns are also supported, like `*_token`.
+	Keys []string
+
+	// Patterns whose matches will be replaced in the field values and messages.
+	Patterns []*regexp.Regexp
+
+	// Default: [REDACTED]
+	Replacement string
+}
+
+// Redactor removes the sensitive data of the fields and messages before encoding.
+type Redactor struct {
+	cfg  RedactorConfig
+	keys []string
+}
+
+// redactedObject wraps an object marshaler, so its nested keys and values are redacted when encoded.
+type redactedObject struct {
+	r     *Redactor
+	value ObjectMarshaler
+}
+
+// redactedArray wraps an array marshaler, so its nested values are redacted when encoded.
+type redactedArray struct {
+	r     *Redactor
+	value ArrayMarshaler
+}
+
+type redactorObjectEncoder struct {
+	r   *Redactor
+	enc ObjectEncoder
+}
+
+type redactorArrayEncoder struct {
+	r   *Redactor
+	enc ArrayEncoder
+}
+
 // Encoder represents the encoders contract.
 type Encoder interface {
 	Copy() Encoder
@@ -110,20 +642,70 @@ type EncoderTextConfig struct {
 
 	// Default: TimestampFormatSeconds
 	TimestampFormat TimestampFormat
+
+	// EscapeMode sets how the control characters of the messages and fields are escaped,
+	// to prevent log injections.
+	//
+	// Use TextEscapeMultiline to keep the new lines, like in stack traces.
+	//
+	// Default: TextEscapeAll
+	EscapeMode TextEscapeMode
+
+	// LevelFormat sets how the level is encoded.
+	//
+	// Default: LevelFormatUpper
+	LevelFormat LevelFormat
+
+	// LevelNames overrides the name of the given levels, over the LevelFormat.
+	// Like {WARNING: "warn", FATAL: "critical"}.
+	//
+	// Default: nil
+	LevelNames map[Level]string
+
+	// PrintLevel encodes the level of the PRINT entries too, which is omitted by default.
+	//
+	// Default: false
+	PrintLevel bool
 }
 
 // EncoderText is the text enconder.
 type EncoderText struct {
 	EncoderBase
 
-	cfg EncoderTextConfig
+	cfg       EncoderTextConfig
+	namespace string
+}
+
+type textObjectEncoder struct {
+	enc    *EncoderText
+	buf    *Buffer
+	prefix string
+	inline bool
+	size   int
+}
+
+type textArrayEncoder struct {
+	enc  *EncoderText
+	buf  *Buffer
+	size int
 }
 
 // EncoderJSON is the json encoder.
 type EncoderJSON struct {
 	EncoderBase
 
-	cfg EncoderJSONConfig
+	cfg        EncoderJSONConfig
+	namespaces int
+}
+
+type jsonObjectEncoder struct {
+	enc *EncoderJSON
+	buf *Buffer
+}
+
+type jsonArrayEncoder struct {
+	enc *EncoderJSON
+	buf *Buffer
 }
 
 // EncoderJSONConfig is the configuration of json encoder.
@@ -135,6 +717,37 @@ type EncoderJSONConfig struct {
 
 	// Default: TimestampFormatSeconds
 	TimestampFormat TimestampFormat
+
+	// KeyCollision sets what to do with the fields whose key collides with a reserved key,
+	// like the level or message keys.
+	//
+	// The namespaces are never dropped, since their fields would lose the nesting,
+	// so they get the KeyCollisionPrefix with KeyCollisionDrop.
+	//
+	// Default: KeyCollisionAddPrefix
+	KeyCollision KeyCollisionPolicy
+
+	// KeyCollisionPrefix is the prefix added to the colliding keys with KeyCollisionAddPrefix.
+	//
+	// Default: fields.
+	KeyCollisionPrefix string
+
+	// LevelFormat sets how the level is encoded.
+	// With LevelFormatNumeric, the level is encoded as a number.
+	//
+	// Default: LevelFormatUpper
+	LevelFormat LevelFormat
+
+	// LevelNames overrides the name of the given levels, over the LevelFormat.
+	// Like {WARNING: "warn", FATAL: "critical"}.
+	//
+	// Default: nil
+	LevelNames map[Level]string
+
+	// PrintLevel encodes the level of the PRINT entries too, which is omitted by default.
+	//
+	// Default: false
+	PrintLevel bool
 }
 
 // EnconderJSONFieldMap defines name of keys.
@@ -2,18 +2,237 @@ package logger
 
 import (
 	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
-func getFileCaller(calldepth int) (frame runtime.Frame) {
-	pc := make([]uintptr, 1)
+var helpers = new(helperRegistry)
 
-	numFrames := runtime.Callers(calldepth, pc)
+var frameCache sync.Map // map[uintptr]*callerFrame
+
+// Helper marks the calling function as a logging helper, like testing.T.Helper,
+// so it's skipped when getting the caller file and function.
+//
+// Call it from the functions which wrap the logger methods.
+func Helper() {
+	var pc [1]uintptr
+
+	if runtime.Callers(2, pc[:]) < 1 { // nolint:gomnd
+		return
+	}
+
+	helpers.mark(pc[0])
+}
+
+func (r *helperRegistry) mark(pc uintptr) {
+	if _, ok := r.pcs.Load(pc); ok {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	if _, loaded := r.funcs.LoadOrStore(frame.Function, struct{}{}); !loaded {
+		atomic.AddInt32(&r.count, 1)
+	}
+
+	r.pcs.Store(pc, struct{}{})
+}
+
+func (r *helperRegistry) empty() bool {
+	return atomic.LoadInt32(&r.count) == 0
+}
+
+func (r *helperRegistry) isHelper(function string) bool {
+	_, ok := r.funcs.Load(function)
+
+	return ok
+}
+
+var unknownCallerFrame = &callerFrame{
+	frame:     runtime.Frame{File: unknownFile, Line: 0},
+	shortFile: unknownFile,
+}
+
+// getFileCaller returns the first caller from the calldepth which is not a helper function,
+// using pcs as scratch space.
+//
+// The frames are cached by program counter, so they are only resolved once per call site.
+func getFileCaller(pcs []uintptr, calldepth int) *callerFrame {
+	if len(pcs) == 0 {
+		pcs = make([]uintptr, maxCallerDepth)
+	}
+
+	depth := 1
+	if !helpers.empty() {
+		depth = len(pcs)
+	}
+
+	numFrames := runtime.Callers(calldepth, pcs[:depth])
 	if numFrames < 1 {
-		frame.File = unknownFile
-		frame.Line = 0
+		return unknownCallerFrame
+	}
+
+	var caller *callerFrame
+
+	for _, pc := range pcs[:numFrames] {
+		if caller = cachedFrame(pc); !caller.helper {
+			break
+		}
+	}
+
+	return caller
+}
+
+// cachedFrame returns the frame of the given program counter, resolving it only once,
+// and checking again if it's a helper when the helpers change.
+func cachedFrame(pc uintptr) *callerFrame {
+	gen := atomic.LoadInt32(&helpers.count)
+
+	if v, ok := frameCache.Load(pc); ok {
+		cached := v.(*callerFrame) // nolint:forcetypeassert
+		if cached.helperGen == gen {
+			return cached
+		}
+
+		caller := *cached
+		caller.helper = helpers.isHelper(caller.frame.Function)
+		caller.helperGen = gen
+
+		frameCache.Store(pc, &caller)
+
+		return &caller
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	caller := &callerFrame{
+		frame:     frame,
+		shortFile: shortFile(frame.File),
+		helper:    helpers.isHelper(frame.Function),
+		helperGen: gen,
+	}
+
+	frameCache.Store(pc, caller)
+
+	return caller
+}
+
+// shortFile returns the file name without the directories.
+func shortFile(file string) string {
+	for i := len(file) - 1; i > 0; i-- {
+		if file[i] == '/' {
+			return file[i+1:]
+		}
+	}
+
+	return file
+}
+
+// appendDuration appends the duration formatted like time.Duration.String, without allocations.
+func appendDuration(dst []byte, d time.Duration) []byte { // nolint:cyclop
+	var buf [32]byte
+
+	w := len(buf)
+	u := uint64(d)
+
+	neg := d < 0
+	if neg {
+		u = -u
+	}
+
+	if u < uint64(time.Second) {
+		var prec int
+
+		w--
+		buf[w] = 's'
+		w--
+
+		switch {
+		case u == 0:
+			return append(dst, '0', 's')
+		case u < uint64(time.Microsecond):
+			prec = 0
+			buf[w] = 'n'
+		case u < uint64(time.Millisecond):
+			prec = 3
+			w-- // NOTE: Need room for two bytes.
+			copy(buf[w:], "µ")
+		default:
+			prec = 6
+			buf[w] = 'm'
+		}
+
+		w, u = fmtFrac(buf[:w], u, prec)
+		w = fmtInt(buf[:w], u)
+	} else {
+		w--
+		buf[w] = 's'
+
+		w, u = fmtFrac(buf[:w], u, 9)
+		w = fmtInt(buf[:w], u%60)
+
+		if u /= 60; u > 0 {
+			w--
+			buf[w] = 'm'
+			w = fmtInt(buf[:w], u%60)
+
+			if u /= 60; u > 0 {
+				w--
+				buf[w] = 'h'
+				w = fmtInt(buf[:w], u)
+			}
+		}
+	}
+
+	if neg {
+		w--
+		buf[w] = '-'
+	}
+
+	return append(dst, buf[w:]...)
+}
+
+// fmtFrac formats the fraction of v/10**prec (e.g., ".12345") into the tail of buf,
+// omitting trailing zeros. It returns the index where the output begins and v/10**prec.
+func fmtFrac(buf []byte, v uint64, prec int) (int, uint64) {
+	w := len(buf)
+	print := false
+
+	for i := 0; i < prec; i++ {
+		digit := v % 10
+		print = print || digit != 0
+
+		if print {
+			w--
+			buf[w] = byte(digit) + '0'
+		}
+
+		v /= 10
+	}
+
+	if print {
+		w--
+		buf[w] = '.'
+	}
+
+	return w, v
+}
+
+// fmtInt formats v into the tail of buf. It returns the index where the output begins.
+func fmtInt(buf []byte, v uint64) int {
+	w := len(buf)
+
+	if v == 0 {
+		w--
+		buf[w] = '0'
 	} else {
-		frame, _ = runtime.CallersFrames(pc).Next()
+		for v > 0 {
+			w--
+			buf[w] = byte(v%10) + '0'
+			v /= 10
+		}
 	}
 
-	return frame
+	return w
 }
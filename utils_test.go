@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -33,7 +34,7 @@ func Test_getFileCaller(t *testing.T) { // nolint:funlen
 			want: want{
 				frame: runtime.Frame{
 					File: filepath.Join(cwd, "utils_test.go"),
-					Line: 60,
+					Line: 61,
 				},
 			},
 		},
@@ -57,7 +58,7 @@ func Test_getFileCaller(t *testing.T) { // nolint:funlen
 		t.Run(test.name, func(t *testing.T) {
 			t.Helper()
 
-			frame := getFileCaller(test.args.calldepth)
+			frame := getFileCaller(nil, test.args.calldepth).frame
 
 			frameFile := filepath.ToSlash(frame.File)
 			wantFile := filepath.ToSlash(test.want.frame.File)
@@ -72,3 +73,164 @@ func Test_getFileCaller(t *testing.T) { // nolint:funlen
 		})
 	}
 }
+
+func Test_cachedFrame(t *testing.T) {
+	pcs := make([]uintptr, 1)
+	runtime.Callers(1, pcs)
+
+	frameCache.Delete(pcs[0])
+
+	caller := cachedFrame(pcs[0])
+
+	cached, ok := frameCache.Load(pcs[0])
+	if !ok {
+		t.Fatal("the frame is not cached")
+	}
+
+	if cached.(*callerFrame) != caller { // nolint:forcetypeassert
+		t.Errorf("cached frame == %v, want %v", cached, caller)
+	}
+
+	wantFrame, _ := runtime.CallersFrames(pcs).Next()
+	if frame := caller.frame; frame.Function != wantFrame.Function || frame.File != wantFrame.File ||
+		frame.Line != wantFrame.Line {
+		t.Errorf("frame == %v, want %v", frame, wantFrame)
+	}
+
+	if wantShortFile := filepath.Base(wantFrame.File); caller.shortFile != wantShortFile {
+		t.Errorf("short file == %s, want %s", caller.shortFile, wantShortFile)
+	}
+
+	if again := cachedFrame(pcs[0]); again != caller {
+		t.Errorf("frame == %v, want %v", again, caller)
+	}
+}
+
+func testCachedFrameHelperPC() uintptr {
+	pcs := make([]uintptr, 1)
+	runtime.Callers(1, pcs)
+
+	return pcs[0]
+}
+
+func Test_cachedFrame_helper(t *testing.T) {
+	pc := testCachedFrameHelperPC()
+
+	// NOTE: The helpers can't be unmarked, so it's already a helper on the next runs, like with -count.
+	if caller := cachedFrame(pc); caller.helper != helpers.isHelper(caller.frame.Function) {
+		t.Fatalf("helper == %t, want %t", caller.helper, !caller.helper)
+	}
+
+	helpers.mark(pc)
+
+	if !cachedFrame(pc).helper {
+		t.Error("the frame is not a helper after marking it")
+	}
+}
+
+func Test_shortFile(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{file: "/go/src/logger/utils.go", want: "utils.go"},
+		{file: "utils.go", want: "utils.go"},
+		{file: unknownFile, want: unknownFile},
+	}
+
+	for _, test := range tests {
+		if result := shortFile(test.file); result != test.want {
+			t.Errorf("shortFile(%s) == %s, want %s", test.file, result, test.want)
+		}
+	}
+}
+
+func testHelperInfo(l *Logger, msg string) {
+	Helper()
+
+	l.Info(msg)
+}
+
+func testHelperInfoNested(l *Logger, msg string) {
+	Helper()
+
+	testHelperInfo(l, msg)
+}
+
+func TestHelper(t *testing.T) {
+	l := newTestLogger()
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{
+			name: "helper",
+			fn:   func() { testHelperInfo(l, "hello") },
+		},
+		{
+			name: "nested helpers",
+			fn:   func() { testHelperInfoNested(l, "hello") },
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+
+		t.Run(test.name, func(t *testing.T) {
+			entry := testLoggerCallerEntry(l, test.fn)
+
+			wantFunction := "github.com/savsgio/go-logger/v4.TestHelper.func"
+			if !strings.HasPrefix(entry.Caller.Function, wantFunction) {
+				t.Errorf("caller function == %s, want prefix %s", entry.Caller.Function, wantFunction)
+			}
+
+			if entry.Caller.File == unknownFile || entry.Caller.Line == 0 {
+				t.Errorf("caller == %s:%d, want a known file", entry.Caller.File, entry.Caller.Line)
+			}
+		})
+	}
+
+	if !helpers.isHelper("github.com/savsgio/go-logger/v4.testHelperInfo") {
+		t.Error("testHelperInfo is not marked as helper")
+	}
+
+	if helpers.isHelper("github.com/savsgio/go-logger/v4.testLoggerInfoWrapper") {
+		t.Error("testLoggerInfoWrapper is marked as helper")
+	}
+}
+
+func Benchmark_getFileCaller(b *testing.B) {
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			pc := make([]uintptr, 1)
+			runtime.Callers(2, pc)
+			runtime.CallersFrames(pc).Next()
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		pcs := make([]uintptr, maxCallerDepth)
+
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			getFileCaller(pcs, 2)
+		}
+	})
+
+	// NOTE: The helpers can't be unmarked, so it must be the last one.
+	b.Run("cached with helpers", func(b *testing.B) {
+		pcs := make([]uintptr, maxCallerDepth)
+
+		helpers.mark(testCachedFrameHelperPC())
+
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			getFileCaller(pcs, 2)
+		}
+	})
+}